@@ -0,0 +1,116 @@
+// Call the actions and connectors API of Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/actions-and-connectors-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaConnectorTypes = "/api/actions/connector_types"                                             // Base URL to access on connector types
+	basePathKibanaConnectors     = "/api/actions/connectors"                                                  // Base URL to access on connectors
+	basePathKibanaConnector      = "/api/actions/connector"                                                   // Base URL to access on connector
+	basePathKibanaConnectorApp   = "/app/management/insightsAndAlerting/triggersActionsConnectors/connectors" // URL of connector page on Kibana UI
+)
+
+// kibanaConnectorType is one connector type
+type kibanaConnectorType struct {
+	ID                     string   `json:"id"`
+	Name                   string   `json:"name"`
+	Enabled                bool     `json:"enabled"`
+	EnabledInConfig        bool     `json:"enabled_in_config"`
+	EnabledInLicense       bool     `json:"enabled_in_license"`
+	MinimumLicenseRequired string   `json:"minimum_license_required"`
+	SupportedFeatureIDs    []string `json:"supported_feature_ids"`
+	IsSystemActionType     bool     `json:"is_system_action_type"`
+}
+
+// kibanaConnector is one connector, as returned by get all connectors API
+type kibanaConnector struct {
+	ID                string         `json:"id"`
+	Name              string         `json:"name"`
+	ConnectorTypeID   string         `json:"connector_type_id"`
+	IsPreconfigured   bool           `json:"is_preconfigured"`
+	IsDeprecated      bool           `json:"is_deprecated"`
+	ReferencedByCount int            `json:"referenced_by_count"`
+	Config            map[string]any `json:"config,omitempty"`
+}
+
+// kibanaConnectorCreation is the body to create connector
+type kibanaConnectorCreation struct {
+	Name            string         `json:"name"`
+	ConnectorTypeID string         `json:"connector_type_id"`
+	Config          map[string]any `json:"config"`
+	Secrets         map[string]any `json:"secrets"`
+}
+
+// listKibanaConnectorTypes permit to get all connector types in space, optionally only them that support feature
+func listKibanaConnectorTypes(c *resty.Client, space string, featureID string) ([]kibanaConnectorType, error) {
+	path := buildSpacePath(space, basePathKibanaConnectorTypes)
+	log.Debugf("URL to list connector types: %s", path)
+
+	req := c.R()
+	if featureID != "" {
+		req.SetQueryParam("feature_id", featureID)
+	}
+	resp, err := req.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	connectorTypes := make([]kibanaConnectorType, 0)
+	if err = json.Unmarshal(resp.Body(), &connectorTypes); err != nil {
+		return nil, err
+	}
+
+	return connectorTypes, nil
+}
+
+// listKibanaConnectors permit to get all connectors in space, with the number of rules that reference them
+func listKibanaConnectors(c *resty.Client, space string) ([]kibanaConnector, error) {
+	path := buildSpacePath(space, basePathKibanaConnectors)
+	log.Debugf("URL to list connectors: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	connectors := make([]kibanaConnector, 0)
+	if err = json.Unmarshal(resp.Body(), &connectors); err != nil {
+		return nil, err
+	}
+
+	return connectors, nil
+}
+
+// createKibanaConnector permit to create connector with the given ID
+func createKibanaConnector(c *resty.Client, space string, id string, connector *kibanaConnectorCreation) error {
+	path := buildSpacePath(space, basePathKibanaConnector, id)
+	log.Debugf("URL to create connector: %s", path)
+
+	resp, err := c.R().SetBody(connector).Post(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		// Body explain why config or secrets are invalid
+		return kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+
+	return nil
+}
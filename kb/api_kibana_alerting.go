@@ -0,0 +1,536 @@
+// Call the alerting API of Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/master/alerting-apis.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaAlertingGlobalExecutionLogs = "/internal/alerting/_global_execution_logs"                // Base URL to access on rule execution logs of all rules
+	basePathKibanaAlertingGlobalExecutionKPI  = "/internal/alerting/_global_execution_kpi"                 // Base URL to access on rule execution KPI of all rules
+	basePathKibanaAlertingRule                = "/api/alerting/rule"                                       // Base URL to access on rule
+	basePathKibanaAlertingRuleTypes           = "/api/alerting/rule_types"                                 // Base URL to access on rule types
+	basePathKibanaAlertingRules               = "/api/alerting/rules"                                      // Base URL to find rules
+	basePathKibanaAlertingRulesBulkDelete     = "/internal/alerting/rules/_bulk_delete"                    // Base URL to delete many rules
+	basePathKibanaAlertingRulesBulkEdit       = "/internal/alerting/rules/_bulk_edit"                      // Base URL to edit many rules
+	basePathKibanaRuleApp                     = "/app/management/insightsAndAlerting/triggersActions/rule" // URL of rule page on Kibana UI
+)
+
+// kibanaExecutionLogParameters is the filters used to read the execution logs
+type kibanaExecutionLogParameters struct {
+	DateStart string
+	DateEnd   string
+	Filter    string
+}
+
+// kibanaExecutionLog is one rule execution
+type kibanaExecutionLog struct {
+	ID                  string `json:"id"`
+	Timestamp           string `json:"timestamp"`
+	DurationMs          int64  `json:"duration_ms"`
+	Status              string `json:"status"`
+	Message             string `json:"message"`
+	NumActiveAlerts     int64  `json:"num_active_alerts"`
+	NumNewAlerts        int64  `json:"num_new_alerts"`
+	NumRecoveredAlerts  int64  `json:"num_recovered_alerts"`
+	NumTriggeredActions int64  `json:"num_triggered_actions"`
+	NumErroredActions   int64  `json:"num_errored_actions"`
+	TimedOut            bool   `json:"timed_out"`
+	RuleID              string `json:"rule_id"`
+	RuleName            string `json:"rule_name"`
+}
+
+// kibanaExecutionLogs is one page of rule executions
+type kibanaExecutionLogs struct {
+	Total int                  `json:"total"`
+	Data  []kibanaExecutionLog `json:"data"`
+}
+
+// kibanaExecutionKPI is the summary of rule executions
+type kibanaExecutionKPI struct {
+	Success          int64 `json:"success"`
+	Unknown          int64 `json:"unknown"`
+	Failure          int64 `json:"failure"`
+	Warning          int64 `json:"warning"`
+	ActiveAlerts     int64 `json:"activeAlerts"`
+	NewAlerts        int64 `json:"newAlerts"`
+	RecoveredAlerts  int64 `json:"recoveredAlerts"`
+	ErroredActions   int64 `json:"erroredActions"`
+	TriggeredActions int64 `json:"triggeredActions"`
+}
+
+// kibanaAlertingRule is one rule
+type kibanaAlertingRule struct {
+	ID              string                        `json:"id,omitempty"`
+	Name            string                        `json:"name"`
+	RuleTypeID      string                        `json:"rule_type_id,omitempty"`
+	Consumer        string                        `json:"consumer,omitempty"`
+	Enabled         *bool                         `json:"enabled,omitempty"`
+	Tags            []string                      `json:"tags"`
+	Schedule        kibanaAlertingRuleSchedule    `json:"schedule"`
+	Params          map[string]any                `json:"params"`
+	Actions         []kibanaAlertingRuleAction    `json:"actions"`
+	NotifyWhen      string                        `json:"notify_when,omitempty"`
+	Throttle        *string                       `json:"throttle,omitempty"`
+	ExecutionStatus *kibanaAlertingRuleExecStatus `json:"execution_status,omitempty"`
+	Revision        int                           `json:"revision,omitempty"`
+}
+
+// kibanaAlertingRules is one page of rules
+type kibanaAlertingRules struct {
+	Total int                  `json:"total"`
+	Data  []kibanaAlertingRule `json:"data"`
+}
+
+// kibanaAlertingRuleSchedule is the interval between rule executions
+type kibanaAlertingRuleSchedule struct {
+	Interval string `json:"interval"`
+}
+
+// kibanaAlertingRuleAction is one action run by rule
+type kibanaAlertingRuleAction struct {
+	ID        string                             `json:"id"`
+	Group     string                             `json:"group"`
+	Params    map[string]any                     `json:"params"`
+	Frequency *kibanaAlertingRuleActionFrequency `json:"frequency,omitempty"`
+}
+
+// kibanaAlertingRuleActionFrequency is when action run. Serverless only support it instead of notify_when and throttle of rule
+type kibanaAlertingRuleActionFrequency struct {
+	Summary    bool    `json:"summary"`
+	NotifyWhen string  `json:"notify_when"`
+	Throttle   *string `json:"throttle"`
+}
+
+// kibanaAlertingRuleType is one rule type registered on Kibana
+type kibanaAlertingRuleType struct {
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	EnabledInLicense       bool   `json:"enabled_in_license"`
+	MinimumLicenseRequired string `json:"minimum_license_required"`
+}
+
+// kibanaAlertingRuleExecStatus is the status of the last rule execution
+type kibanaAlertingRuleExecStatus struct {
+	Status        string `json:"status"`
+	LastExecution string `json:"last_execution_date"`
+}
+
+// queryParams return the execution log parameters as query parameters
+func (p *kibanaExecutionLogParameters) queryParams() map[string]string {
+	queryParams := map[string]string{
+		"date_start": p.DateStart,
+	}
+	if p.DateEnd != "" {
+		queryParams["date_end"] = p.DateEnd
+	}
+	if p.Filter != "" {
+		queryParams["filter"] = p.Filter
+	}
+
+	return queryParams
+}
+
+// getKibanaGlobalExecutionLogs permit to get one page of execution logs of all rules in space
+func getKibanaGlobalExecutionLogs(c *resty.Client, space string, parameters *kibanaExecutionLogParameters, page int, perPage int) (*kibanaExecutionLogs, error) {
+	path := buildSpacePath(space, basePathKibanaAlertingGlobalExecutionLogs)
+	log.Debugf("URL to get execution logs: %s", path)
+
+	resp, err := c.R().
+		SetHeader("x-elastic-internal-origin", "kibana").
+		SetQueryParams(parameters.queryParams()).
+		SetQueryParam("page", strconv.Itoa(page)).
+		SetQueryParam("per_page", strconv.Itoa(perPage)).
+		Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	executionLogs := &kibanaExecutionLogs{}
+	if err = json.Unmarshal(resp.Body(), executionLogs); err != nil {
+		return nil, err
+	}
+
+	return executionLogs, nil
+}
+
+// getKibanaGlobalExecutionKPI permit to get the execution KPI of all rules in space
+func getKibanaGlobalExecutionKPI(c *resty.Client, space string, parameters *kibanaExecutionLogParameters) (*kibanaExecutionKPI, error) {
+	path := buildSpacePath(space, basePathKibanaAlertingGlobalExecutionKPI)
+	log.Debugf("URL to get execution KPI: %s", path)
+
+	resp, err := c.R().
+		SetHeader("x-elastic-internal-origin", "kibana").
+		SetQueryParams(parameters.queryParams()).
+		Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	executionKPI := &kibanaExecutionKPI{}
+	if err = json.Unmarshal(resp.Body(), executionKPI); err != nil {
+		return nil, err
+	}
+
+	return executionKPI, nil
+}
+
+// getKibanaAlertingRule permit to get rule. It return nil if not found
+func getKibanaAlertingRule(c *resty.Client, space string, id string) (*kibanaAlertingRule, error) {
+	path := buildSpacePath(space, basePathKibanaAlertingRule, id)
+	log.Debugf("URL to get rule: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	rule := &kibanaAlertingRule{}
+	if err = json.Unmarshal(resp.Body(), rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// createKibanaAlertingRule permit to create rule. Kibana generate the rule ID when it's not set
+func createKibanaAlertingRule(c *resty.Client, space string, rule *kibanaAlertingRule) (*kibanaAlertingRule, error) {
+	path := buildSpacePath(space, basePathKibanaAlertingRule)
+	body := *rule
+	body.Revision = 0
+	if rule.ID != "" {
+		path = buildSpacePath(space, basePathKibanaAlertingRule, rule.ID)
+		body.ID = ""
+	}
+	log.Debugf("URL to create rule: %s", path)
+
+	resp, err := c.R().SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		// Body explain why params are invalid
+		return nil, kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+	rule = &kibanaAlertingRule{}
+	if err = json.Unmarshal(resp.Body(), rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// updateKibanaAlertingRule permit to update rule.
+// Rule type, consumer and enabled can't be updated with this API
+func updateKibanaAlertingRule(c *resty.Client, space string, rule *kibanaAlertingRule) error {
+	path := buildSpacePath(space, basePathKibanaAlertingRule, rule.ID)
+	log.Debugf("URL to update rule: %s", path)
+
+	body := *rule
+	body.ID = ""
+	body.RuleTypeID = ""
+	body.Consumer = ""
+	body.Enabled = nil
+	body.ExecutionStatus = nil
+	body.Revision = 0
+
+	resp, err := c.R().SetBody(body).Put(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+
+	return nil
+}
+
+// kibanaAlertingRuleUpdateFields are the rule fields accepted by update API
+var kibanaAlertingRuleUpdateFields = []string{"name", "tags", "schedule", "params", "actions", "notify_when", "throttle", "alert_delay", "flapping"}
+
+// kibanaAlertingRuleActionUpdateFields are the action fields accepted by update API
+var kibanaAlertingRuleActionUpdateFields = []string{"id", "group", "params", "frequency", "uuid", "alerts_filter", "use_alert_data_for_template"}
+
+// removeKibanaAlertingRuleActions permit to remove the actions that match from rule.
+// The rule is updated from its raw JSON, so the fields not modeled by kibanaAlertingRule, like alerts_filter or flapping, are kept.
+// It return the number of actions removed
+func removeKibanaAlertingRuleActions(c *resty.Client, space string, id string, match func(action *kibanaAlertingRuleAction) bool) (int, error) {
+	path := buildSpacePath(space, basePathKibanaAlertingRule, id)
+	log.Debugf("URL to get rule: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return 0, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return 0, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	rule := map[string]any{}
+	if err = json.Unmarshal(resp.Body(), &rule); err != nil {
+		return 0, err
+	}
+
+	body, removed, err := buildKibanaAlertingRuleUpdateWithoutActions(rule, match)
+	if err != nil || removed == 0 {
+		return removed, err
+	}
+
+	log.Debugf("URL to update rule: %s", path)
+	resp, err = c.R().SetBody(body).Put(path)
+	if err != nil {
+		return 0, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return 0, kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+
+	return removed, nil
+}
+
+// buildKibanaAlertingRuleUpdateWithoutActions return the update body of raw rule, without the actions that match.
+// Only the fields accepted by update API are kept, with their raw values
+func buildKibanaAlertingRuleUpdateWithoutActions(rule map[string]any, match func(action *kibanaAlertingRuleAction) bool) (map[string]any, int, error) {
+	body := make(map[string]any, len(kibanaAlertingRuleUpdateFields))
+	for _, field := range kibanaAlertingRuleUpdateFields {
+		if value, ok := rule[field]; ok && value != nil {
+			body[field] = value
+		}
+	}
+
+	rawActions, _ := rule["actions"].([]any)
+	actions := make([]any, 0, len(rawActions))
+	removed := 0
+	for _, rawAction := range rawActions {
+		rawActionMap, ok := rawAction.(map[string]any)
+		if !ok {
+			return nil, 0, fmt.Errorf("Unexpected action on rule %v: %+v", rule["id"], rawAction)
+		}
+		data, err := json.Marshal(rawActionMap)
+		if err != nil {
+			return nil, 0, err
+		}
+		action := &kibanaAlertingRuleAction{}
+		if err = json.Unmarshal(data, action); err != nil {
+			return nil, 0, err
+		}
+		if match(action) {
+			removed++
+			continue
+		}
+
+		updateAction := make(map[string]any, len(kibanaAlertingRuleActionUpdateFields))
+		for _, field := range kibanaAlertingRuleActionUpdateFields {
+			if value, ok := rawActionMap[field]; ok && value != nil {
+				updateAction[field] = value
+			}
+		}
+		actions = append(actions, updateAction)
+	}
+	body["actions"] = actions
+
+	return body, removed, nil
+}
+
+// enableKibanaAlertingRule permit to enable or disable rule
+func enableKibanaAlertingRule(c *resty.Client, space string, id string, enabled bool) error {
+	action := "_disable"
+	if enabled {
+		action = "_enable"
+	}
+	path := buildSpacePath(space, basePathKibanaAlertingRule, id, action)
+	log.Debugf("URL to enable rule: %s", path)
+
+	resp, err := c.R().Post(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+// deleteKibanaAlertingRule permit to delete rule
+func deleteKibanaAlertingRule(c *resty.Client, space string, id string) error {
+	path := buildSpacePath(space, basePathKibanaAlertingRule, id)
+	log.Debugf("URL to delete rule: %s", path)
+
+	resp, err := c.R().Delete(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+// kibanaAlertingRulesBulkDeleteError is the error of one rule not deleted by bulk delete, or not edited by bulk edit
+type kibanaAlertingRulesBulkDeleteError struct {
+	Message string `json:"message"`
+	Status  int    `json:"status,omitempty"`
+	Rule    struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"rule"`
+}
+
+// bulkDeleteKibanaAlertingRules permit to delete many rules with one call.
+// It return the error of each rule not deleted, by rule ID
+func bulkDeleteKibanaAlertingRules(c *resty.Client, space string, ids []string) (map[string]error, error) {
+	path := buildSpacePath(space, basePathKibanaAlertingRulesBulkDelete)
+	log.Debugf("URL to bulk delete rules: %s", path)
+
+	resp, err := c.R().
+		SetHeader("x-elastic-internal-origin", "kibana").
+		SetBody(map[string]any{"ids": ids}).
+		Patch(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+	data := struct {
+		Errors []kibanaAlertingRulesBulkDeleteError `json:"errors"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return nil, err
+	}
+
+	errs := make(map[string]error, len(data.Errors))
+	for _, ruleError := range data.Errors {
+		status := ruleError.Status
+		if status == 0 {
+			status = 500
+		}
+		errs[ruleError.Rule.ID] = kbapi.NewAPIError(status, "%s", ruleError.Message)
+	}
+
+	return errs, nil
+}
+
+// listKibanaAlertingRuleTypes permit to get all rule types registered on Kibana
+func listKibanaAlertingRuleTypes(c *resty.Client, space string) ([]kibanaAlertingRuleType, error) {
+	path := buildSpacePath(space, basePathKibanaAlertingRuleTypes)
+	log.Debugf("URL to list rule types: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	ruleTypes := make([]kibanaAlertingRuleType, 0)
+	if err = json.Unmarshal(resp.Body(), &ruleTypes); err != nil {
+		return nil, err
+	}
+
+	return ruleTypes, nil
+}
+
+// findKibanaAlertingRules permit to get one page of rules in space, sorted by ID.
+// The filter is KQL on rule attributes, like `alert.attributes.tags:"prod"`. All rules are returned when it's empty
+func findKibanaAlertingRules(c *resty.Client, space string, filter string, page int, perPage int) (*kibanaAlertingRules, error) {
+	path := buildSpacePath(space, basePathKibanaAlertingRules, "_find")
+	log.Debugf("URL to find rules: %s", path)
+
+	req := c.R().
+		SetQueryParam("page", strconv.Itoa(page)).
+		SetQueryParam("per_page", strconv.Itoa(perPage)).
+		SetQueryParam("sort_field", "id")
+	if filter != "" {
+		req.SetQueryParam("filter", filter)
+	}
+	resp, err := req.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	rules := &kibanaAlertingRules{}
+	if err = json.Unmarshal(resp.Body(), rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// kibanaAlertingRulesBulkEditOperation is one edition applied by bulk edit
+type kibanaAlertingRulesBulkEditOperation struct {
+	Operation string `json:"operation"`
+	Field     string `json:"field"`
+	Value     any    `json:"value"`
+}
+
+// bulkEditKibanaAlertingRules permit to apply the same editions on many rules with one call.
+// It return the number of rules edited, and the error of each rule not edited, by rule ID
+func bulkEditKibanaAlertingRules(c *resty.Client, space string, ids []string, operations []kibanaAlertingRulesBulkEditOperation) (int, map[string]error, error) {
+	path := buildSpacePath(space, basePathKibanaAlertingRulesBulkEdit)
+	log.Debugf("URL to bulk edit rules: %s", path)
+
+	resp, err := c.R().
+		SetHeader("x-elastic-internal-origin", "kibana").
+		SetBody(map[string]any{"ids": ids, "operations": operations}).
+		Post(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return 0, nil, kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+	data := struct {
+		Rules  []kibanaAlertingRule                 `json:"rules"`
+		Errors []kibanaAlertingRulesBulkDeleteError `json:"errors"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return 0, nil, err
+	}
+
+	errs := make(map[string]error, len(data.Errors))
+	for _, ruleError := range data.Errors {
+		status := ruleError.Status
+		if status == 0 {
+			status = 500
+		}
+		errs[ruleError.Rule.ID] = kbapi.NewAPIError(status, "%s", ruleError.Message)
+	}
+
+	return len(data.Rules), errs, nil
+}
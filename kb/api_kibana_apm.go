@@ -0,0 +1,67 @@
+// Call the APM API of Kibana
+// API documentation: not documented, internal API used by APM UI
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaAPMIndexSettings = "/internal/apm/settings/apm-index-settings" // Base URL to read APM index settings
+	basePathKibanaAPMIndicesSave   = "/internal/apm/settings/apm-indices/save"   // Base URL to save APM index settings
+)
+
+// getKibanaAPMIndexSettings permit to get the APM index settings saved by user, indexed by configuration name
+func getKibanaAPMIndexSettings(c *resty.Client) (map[string]string, error) {
+	resp, err := c.R().
+		SetHeader("x-elastic-internal-origin", "kibana").
+		Get(buildPath(basePathKibanaAPMIndexSettings))
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	data := struct {
+		APMIndexSettings []struct {
+			ConfigurationName string `json:"configurationName"`
+			SavedValue        string `json:"savedValue"`
+		} `json:"apmIndexSettings"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]string, len(data.APMIndexSettings))
+	for _, setting := range data.APMIndexSettings {
+		settings[setting.ConfigurationName] = setting.SavedValue
+	}
+	log.Debug("APM index settings: ", settings)
+
+	return settings, nil
+}
+
+// saveKibanaAPMIndexSettings permit to save the APM index settings. Empty setting use the default value
+func saveKibanaAPMIndexSettings(c *resty.Client, settings map[string]string) error {
+	resp, err := c.R().
+		SetHeader("x-elastic-internal-origin", "kibana").
+		SetBody(settings).
+		Post(buildPath(basePathKibanaAPMIndicesSave))
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
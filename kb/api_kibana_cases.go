@@ -0,0 +1,142 @@
+// Call the cases API of Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/cases-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaCases = "/api/cases" // Base URL to access on cases
+)
+
+// kibanaCase is one case
+type kibanaCase struct {
+	ID          string               `json:"id,omitempty"`
+	Version     string               `json:"version,omitempty"`
+	Owner       string               `json:"owner,omitempty"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	Tags        []string             `json:"tags"`
+	Status      string               `json:"status,omitempty"`
+	Severity    string               `json:"severity,omitempty"`
+	Assignees   []kibanaCaseAssignee `json:"assignees"`
+	Connector   kibanaCaseConnector  `json:"connector"`
+	Settings    kibanaCaseSettings   `json:"settings"`
+}
+
+// kibanaCaseAssignee is the user assigned on case
+type kibanaCaseAssignee struct {
+	UID string `json:"uid"`
+}
+
+// kibanaCaseConnector is the external incident management system linked with case
+type kibanaCaseConnector struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Fields map[string]string `json:"fields"`
+}
+
+// kibanaCaseSettings is the settings of case
+type kibanaCaseSettings struct {
+	SyncAlerts bool `json:"syncAlerts"`
+}
+
+// getKibanaCase permit to get case without comments. It return nil if not found
+func getKibanaCase(c *resty.Client, space string, id string) (*kibanaCase, error) {
+	path := buildSpacePath(space, basePathKibanaCases, id)
+	log.Debugf("URL to get case: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	kCase := &kibanaCase{}
+	if err = json.Unmarshal(resp.Body(), kCase); err != nil {
+		return nil, err
+	}
+
+	return kCase, nil
+}
+
+// createKibanaCase permit to create case
+func createKibanaCase(c *resty.Client, space string, kCase *kibanaCase) (*kibanaCase, error) {
+	path := buildSpacePath(space, basePathKibanaCases)
+	log.Debugf("URL to create case: %s", path)
+
+	// Status can't be set on creation
+	body := *kCase
+	body.Status = ""
+
+	resp, err := c.R().SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	kCase = &kibanaCase{}
+	if err = json.Unmarshal(resp.Body(), kCase); err != nil {
+		return nil, err
+	}
+
+	return kCase, nil
+}
+
+// updateKibanaCase permit to update case. The case version must be the current version on Kibana
+func updateKibanaCase(c *resty.Client, space string, kCase *kibanaCase) error {
+	path := buildSpacePath(space, basePathKibanaCases)
+	log.Debugf("URL to update case: %s", path)
+
+	// Owner can't be updated
+	body := *kCase
+	body.Owner = ""
+
+	resp, err := c.R().SetBody(map[string]any{"cases": []kibanaCase{body}}).Patch(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+// deleteKibanaCase permit to delete case
+func deleteKibanaCase(c *resty.Client, space string, id string) error {
+	path := buildSpacePath(space, basePathKibanaCases)
+	log.Debugf("URL to delete case: %s", path)
+
+	ids, err := json.Marshal([]string{id})
+	if err != nil {
+		return err
+	}
+	resp, err := c.R().SetQueryParam("ids", string(ids)).Delete(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+// Call Elasticsearch through the console proxy of Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/console-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaConsoleProxy = "/api/console/proxy" // Base URL to call Elasticsearch through Kibana
+)
+
+// validateKibanaESQLQuery permit to check ES|QL query on Elasticsearch, without reading any document.
+// The query is run with LIMIT 0, so syntax errors, unknown indices and unknown fields are reported
+func validateKibanaESQLQuery(c *resty.Client, query string) error {
+	path := buildPath(basePathKibanaConsoleProxy)
+	log.Debugf("URL to validate ES|QL query: %s", path)
+
+	resp, err := c.R().
+		SetQueryParams(map[string]string{
+			"path":   "/_query",
+			"method": "POST",
+		}).
+		SetBody(map[string]any{
+			"query": buildKibanaESQLValidationQuery(query),
+		}).
+		Post(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), "%s", parseKibanaESQLError(resp.Status(), resp.Body()))
+	}
+
+	return nil
+}
+
+// buildKibanaESQLValidationQuery return the ES|QL query that return no rows
+func buildKibanaESQLValidationQuery(query string) string {
+	return fmt.Sprintf("%s\n| LIMIT 0", strings.TrimSpace(strings.TrimRight(strings.TrimSpace(query), "|")))
+}
+
+// parseKibanaESQLError return the reason of Elasticsearch error, or the status when body is not an Elasticsearch error
+func parseKibanaESQLError(status string, body []byte) string {
+	data := struct {
+		Error struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}{}
+	if err := json.Unmarshal(body, &data); err != nil || data.Error.Reason == "" {
+		return status
+	}
+
+	return fmt.Sprintf("%s: %s", data.Error.Type, data.Error.Reason)
+}
@@ -0,0 +1,100 @@
+// Call the data views API of Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/data-views-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaDataViews       = "/api/data_views"         // Base URL to access on data views
+	basePathKibanaDefaultDataView = "/api/data_views/default" // Base URL to access on default data view
+)
+
+// kibanaDataView is the summary of data view returned by list API
+type kibanaDataView struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Name       string   `json:"name,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// listKibanaDataViews permit to get all data views in space
+func listKibanaDataViews(c *resty.Client, space string) ([]kibanaDataView, error) {
+	path := buildSpacePath(space, basePathKibanaDataViews)
+	log.Debugf("URL to list data views: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	data := struct {
+		DataViews []kibanaDataView `json:"data_view"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return nil, err
+	}
+
+	return data.DataViews, nil
+}
+
+// getKibanaDefaultDataView permit to get the ID of default data view of space.
+// It return empty string when there is no default data view
+func getKibanaDefaultDataView(c *resty.Client, space string) (string, error) {
+	path := buildSpacePath(space, basePathKibanaDefaultDataView)
+	log.Debugf("URL to get default data view: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return "", err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return "", kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	data := struct {
+		DataViewID string `json:"data_view_id"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return "", err
+	}
+
+	return data.DataViewID, nil
+}
+
+// setKibanaDefaultDataView permit to set the default data view of space, even if there is already one.
+// The default data view is unset when id is empty
+func setKibanaDefaultDataView(c *resty.Client, space string, id string) error {
+	path := buildSpacePath(space, basePathKibanaDefaultDataView)
+	log.Debugf("URL to set default data view: %s", path)
+
+	body := map[string]any{
+		"data_view_id": nil,
+		"force":        true,
+	}
+	if id != "" {
+		body["data_view_id"] = id
+	}
+
+	resp, err := c.R().SetBody(body).Post(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+
+	return nil
+}
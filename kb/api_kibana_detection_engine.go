@@ -0,0 +1,257 @@
+// Call the detection engine API of Kibana
+// API documentation: https://www.elastic.co/guide/en/security/current/rule-api-overview.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaDetectionEnginePrepackagedRules = "/api/detection_engine/rules/prepackaged"  // Base URL to access on Elastic prebuilt rules
+	basePathKibanaDetectionEngineRulesBulkAction  = "/api/detection_engine/rules/_bulk_action" // Base URL to apply action on many rules
+	basePathKibanaDetectionEngineRulesPreview     = "/api/detection_engine/rules/preview"      // Base URL to preview rule
+	basePathKibanaFleetPackages                   = "/api/fleet/epm/packages"                  // Base URL to access on Fleet packages
+	kibanaDetectionEnginePackageName              = "security_detection_engine"                // Fleet package that contain the Elastic prebuilt rules
+)
+
+// kibanaPrepackagedRulesStatus is the installation status of Elastic prebuilt rules and timelines
+type kibanaPrepackagedRulesStatus struct {
+	RulesCustomInstalled  int `json:"rules_custom_installed"`
+	RulesInstalled        int `json:"rules_installed"`
+	RulesNotInstalled     int `json:"rules_not_installed"`
+	RulesNotUpdated       int `json:"rules_not_updated"`
+	TimelinesInstalled    int `json:"timelines_installed"`
+	TimelinesNotInstalled int `json:"timelines_not_installed"`
+	TimelinesNotUpdated   int `json:"timelines_not_updated"`
+}
+
+// getKibanaPrepackagedRulesStatus permit to get the installation status of Elastic prebuilt rules in space
+func getKibanaPrepackagedRulesStatus(c *resty.Client, space string) (*kibanaPrepackagedRulesStatus, error) {
+	path := buildSpacePath(space, basePathKibanaDetectionEnginePrepackagedRules, "_status")
+	log.Debugf("URL to get prepackaged rules status: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	status := &kibanaPrepackagedRulesStatus{}
+	if err = json.Unmarshal(resp.Body(), status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// installKibanaPrepackagedRules permit to install and update Elastic prebuilt rules and timelines in space
+func installKibanaPrepackagedRules(c *resty.Client, space string) error {
+	path := buildSpacePath(space, basePathKibanaDetectionEnginePrepackagedRules)
+	log.Debugf("URL to install prepackaged rules: %s", path)
+
+	resp, err := c.R().Put(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+// getKibanaFleetPackageInstalledVersion permit to get the installed version of Fleet package. It return empty string if not installed
+func getKibanaFleetPackageInstalledVersion(c *resty.Client, name string) (string, error) {
+	path := buildPath(basePathKibanaFleetPackages, name)
+	log.Debugf("URL to get Fleet package: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return "", err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return "", nil
+		}
+		return "", kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	data := struct {
+		Item struct {
+			Status           string `json:"status"`
+			InstallationInfo *struct {
+				Version string `json:"version"`
+			} `json:"installationInfo"`
+			SavedObject *struct {
+				Attributes struct {
+					Version string `json:"version"`
+				} `json:"attributes"`
+			} `json:"savedObject"`
+		} `json:"item"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return "", err
+	}
+
+	if data.Item.Status != "installed" {
+		return "", nil
+	}
+	if data.Item.InstallationInfo != nil {
+		return data.Item.InstallationInfo.Version, nil
+	}
+	if data.Item.SavedObject != nil {
+		return data.Item.SavedObject.Attributes.Version, nil
+	}
+
+	return "", nil
+}
+
+// installKibanaFleetPackage permit to install the given version of Fleet package
+func installKibanaFleetPackage(c *resty.Client, name string, version string) error {
+	path := buildPath(basePathKibanaFleetPackages, name, version)
+	log.Debugf("URL to install Fleet package: %s", path)
+
+	resp, err := c.R().
+		SetBody(map[string]any{"force": true}).
+		Post(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+// kibanaDetectionRuleBulkAction is the action to apply on all rules that match the query or the IDs
+type kibanaDetectionRuleBulkAction struct {
+	Action    string                              `json:"action"`
+	Query     string                              `json:"query,omitempty"`
+	IDs       []string                            `json:"ids,omitempty"`
+	Edit      []kibanaDetectionRuleBulkEdit       `json:"edit,omitempty"`
+	Duplicate *kibanaDetectionRuleBulkDuplication `json:"duplicate,omitempty"`
+}
+
+// kibanaDetectionRuleBulkEdit is one edition applied by the edit bulk action
+type kibanaDetectionRuleBulkEdit struct {
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// kibanaDetectionRuleBulkDuplication is the options of the duplicate bulk action
+type kibanaDetectionRuleBulkDuplication struct {
+	IncludeExceptions bool `json:"include_exceptions"`
+}
+
+// kibanaDetectionRuleBulkActionResult is the summary of bulk action
+type kibanaDetectionRuleBulkActionResult struct {
+	Succeeded int
+	Failed    int
+	Skipped   int
+	Total     int
+	Errors    []string
+}
+
+// bulkActionKibanaDetectionRules permit to apply action on detection rules in space
+func bulkActionKibanaDetectionRules(c *resty.Client, space string, bulkAction *kibanaDetectionRuleBulkAction) (*kibanaDetectionRuleBulkActionResult, error) {
+	path := buildSpacePath(space, basePathKibanaDetectionEngineRulesBulkAction)
+	log.Debugf("URL to apply bulk action on rules: %s", path)
+
+	resp, err := c.R().SetBody(bulkAction).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	// Kibana return 500 when some rules failed, with the summary on body
+	if resp.StatusCode() >= 300 && resp.StatusCode() != 500 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	data := struct {
+		Attributes *struct {
+			Summary struct {
+				Failed    int `json:"failed"`
+				Skipped   int `json:"skipped"`
+				Succeeded int `json:"succeeded"`
+				Total     int `json:"total"`
+			} `json:"summary"`
+			Errors []struct {
+				Message string `json:"message"`
+				Rules   []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"rules"`
+			} `json:"errors"`
+		} `json:"attributes"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil || data.Attributes == nil {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	result := &kibanaDetectionRuleBulkActionResult{
+		Succeeded: data.Attributes.Summary.Succeeded,
+		Failed:    data.Attributes.Summary.Failed,
+		Skipped:   data.Attributes.Summary.Skipped,
+		Total:     data.Attributes.Summary.Total,
+		Errors:    make([]string, 0, len(data.Attributes.Errors)),
+	}
+	for _, e := range data.Attributes.Errors {
+		for _, rule := range e.Rules {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s (%s): %s", rule.Name, rule.ID, e.Message))
+		}
+	}
+
+	return result, nil
+}
+
+// kibanaRulePreview is the result of rule preview
+type kibanaRulePreview struct {
+	PreviewID string `json:"previewId"`
+	IsAborted bool   `json:"isAborted"`
+	Logs      []struct {
+		Errors    []string `json:"errors"`
+		Warnings  []string `json:"warnings"`
+		StartedAt string   `json:"startedAt"`
+		Duration  int64    `json:"duration"`
+	} `json:"logs"`
+}
+
+// previewKibanaDetectionRule permit to run rule on past timeframe without writing alerts on alert index
+func previewKibanaDetectionRule(c *resty.Client, space string, rule map[string]any, timeframeEnd string, invocationCount int) (*kibanaRulePreview, error) {
+	path := buildSpacePath(space, basePathKibanaDetectionEngineRulesPreview)
+	log.Debugf("URL to preview rule: %s", path)
+
+	body := make(map[string]any, len(rule)+2)
+	for key, value := range rule {
+		body[key] = value
+	}
+	body["timeframeEnd"] = timeframeEnd
+	body["invocationCount"] = invocationCount
+
+	resp, err := c.R().SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+	preview := &kibanaRulePreview{}
+	if err = json.Unmarshal(resp.Body(), preview); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,86 @@
+// Read the features registered on Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/features-api-get.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaFeatures = "/api/features" // Base URL to access on features
+)
+
+// kibanaFeature is the feature object, with the privileges that can be granted on roles
+type kibanaFeature struct {
+	ID          string                    `json:"id"`
+	Name        string                    `json:"name"`
+	Privileges  map[string]any            `json:"privileges"`
+	SubFeatures []kibanaFeatureSubFeature `json:"subFeatures,omitempty"`
+}
+
+// kibanaFeatureSubFeature is the sub feature object, that add privileges to its feature
+type kibanaFeatureSubFeature struct {
+	Name            string                                  `json:"name"`
+	PrivilegeGroups []kibanaFeatureSubFeaturePrivilegeGroup `json:"privilegeGroups"`
+}
+
+// kibanaFeatureSubFeaturePrivilegeGroup is the group of sub feature privileges
+type kibanaFeatureSubFeaturePrivilegeGroup struct {
+	GroupType  string                             `json:"groupType"`
+	Privileges []kibanaFeatureSubFeaturePrivilege `json:"privileges"`
+}
+
+// kibanaFeatureSubFeaturePrivilege is the sub feature privilege object
+type kibanaFeatureSubFeaturePrivilege struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// privilegeNames return the privileges that can be granted on feature.
+// The feature with sub features can be granted as minimal, to grant the sub feature privileges one by one
+func (f kibanaFeature) privilegeNames() map[string]bool {
+	names := map[string]bool{}
+	for name := range f.Privileges {
+		names[name] = true
+		if len(f.SubFeatures) > 0 {
+			names["minimal_"+name] = true
+		}
+	}
+	for _, subFeature := range f.SubFeatures {
+		for _, group := range subFeature.PrivilegeGroups {
+			for _, privilege := range group.Privileges {
+				names[privilege.ID] = true
+			}
+		}
+	}
+
+	return names
+}
+
+// listKibanaFeatures permit to get all features registered on Kibana
+func listKibanaFeatures(c *resty.Client) ([]kibanaFeature, error) {
+	path := buildPath(basePathKibanaFeatures)
+	log.Debugf("URL to list features: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	features := make([]kibanaFeature, 0)
+	if err = json.Unmarshal(resp.Body(), &features); err != nil {
+		return nil, err
+	}
+
+	return features, nil
+}
@@ -0,0 +1,255 @@
+// Call the infrastructure API of Kibana
+// API documentation: not documented, API used by Kibana Infrastructure UI
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaInfra                       = "/api/infra" // Base URL to access on infrastructure API
+	kibanaInfraCustomDashboardSavedObjectType = "infrastructure-ui-custom-dashboard"
+)
+
+// kibanaInfraCustomDashboard is the link between dashboard and asset details view
+type kibanaInfraCustomDashboard struct {
+	ID                       string `json:"id,omitempty"`
+	AssetType                string `json:"assetType,omitempty"`
+	DashboardSavedObjectID   string `json:"dashboardSavedObjectId"`
+	DashboardFilterAssetByID bool   `json:"dashboardFilterAssetById"`
+}
+
+// listKibanaInfraCustomDashboards permit to get all custom dashboards linked to asset type
+func listKibanaInfraCustomDashboards(c *resty.Client, space string, assetType string) ([]kibanaInfraCustomDashboard, error) {
+	path := buildSpacePath(space, basePathKibanaInfra, assetType, "custom-dashboards")
+	log.Debugf("URL to list custom dashboards: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	customDashboards := make([]kibanaInfraCustomDashboard, 0)
+	if err = json.Unmarshal(resp.Body(), &customDashboards); err != nil {
+		return nil, err
+	}
+
+	return customDashboards, nil
+}
+
+// getKibanaInfraCustomDashboard permit to get custom dashboard by its ID. It return nil if not found
+func getKibanaInfraCustomDashboard(c *resty.Client, space string, assetType string, id string) (*kibanaInfraCustomDashboard, error) {
+	customDashboards, err := listKibanaInfraCustomDashboards(c, space, assetType)
+	if err != nil {
+		return nil, err
+	}
+	for _, customDashboard := range customDashboards {
+		if customDashboard.ID == id {
+			return &customDashboard, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// createKibanaInfraCustomDashboard permit to link dashboard to asset type
+func createKibanaInfraCustomDashboard(c *resty.Client, space string, assetType string, customDashboard *kibanaInfraCustomDashboard) (*kibanaInfraCustomDashboard, error) {
+	path := buildSpacePath(space, basePathKibanaInfra, assetType, "custom-dashboards")
+	log.Debugf("URL to create custom dashboard: %s", path)
+
+	resp, err := c.R().SetBody(customDashboard).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	result := &kibanaInfraCustomDashboard{}
+	if err = json.Unmarshal(resp.Body(), result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// updateKibanaInfraCustomDashboard permit to update the link between dashboard and asset type
+func updateKibanaInfraCustomDashboard(c *resty.Client, space string, assetType string, customDashboard *kibanaInfraCustomDashboard) (*kibanaInfraCustomDashboard, error) {
+	path := buildSpacePath(space, basePathKibanaInfra, assetType, "custom-dashboards", customDashboard.ID)
+	log.Debugf("URL to update custom dashboard: %s", path)
+
+	body := &kibanaInfraCustomDashboard{
+		DashboardSavedObjectID:   customDashboard.DashboardSavedObjectID,
+		DashboardFilterAssetByID: customDashboard.DashboardFilterAssetByID,
+	}
+	resp, err := c.R().SetBody(body).Put(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	result := &kibanaInfraCustomDashboard{}
+	if err = json.Unmarshal(resp.Body(), result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// deleteKibanaInfraCustomDashboard permit to remove the link between dashboard and asset type
+func deleteKibanaInfraCustomDashboard(c *resty.Client, space string, assetType string, id string) error {
+	path := buildSpacePath(space, basePathKibanaInfra, assetType, "custom-dashboards", id)
+	log.Debugf("URL to delete custom dashboard: %s", path)
+
+	resp, err := c.R().Delete(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+const (
+	basePathKibanaInfraLogViews        = "/api/infra/log_views" // Base URL to access on log views
+	basePathKibanaInfraMetricsSource   = "/api/metrics/source"  // Base URL to access on metrics source configuration
+	kibanaLogViewSavedObjectType       = "infrastructure-monitoring-log-view"
+	kibanaMetricsSourceSavedObjectType = "infrastructure-ui-source"
+)
+
+// kibanaLogView is the configuration of Logs UI
+type kibanaLogView struct {
+	Name        string                           `json:"name"`
+	Description string                           `json:"description"`
+	LogIndices  kibanaLogViewIndices             `json:"logIndices"`
+	LogColumns  []map[string]kibanaLogViewColumn `json:"logColumns"`
+}
+
+// kibanaLogViewIndices is the indices read by Logs UI
+type kibanaLogViewIndices struct {
+	Type       string `json:"type"`
+	IndexName  string `json:"indexName,omitempty"`
+	DataViewID string `json:"dataViewId,omitempty"`
+}
+
+// kibanaLogViewColumn is one column displayed by Logs UI
+type kibanaLogViewColumn struct {
+	ID    string `json:"id"`
+	Field string `json:"field,omitempty"`
+}
+
+// kibanaMetricsSource is the configuration of Infrastructure UI
+type kibanaMetricsSource struct {
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	MetricAlias      string `json:"metricAlias"`
+	AnomalyThreshold int    `json:"anomalyThreshold,omitempty"`
+}
+
+// getKibanaLogView permit to get log view. It return nil if not found
+func getKibanaLogView(c *resty.Client, space string, id string) (*kibanaLogView, error) {
+	path := buildSpacePath(space, basePathKibanaInfraLogViews, id)
+	log.Debugf("URL to get log view: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	data := struct {
+		Data struct {
+			Attributes kibanaLogView `json:"attributes"`
+		} `json:"data"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return nil, err
+	}
+
+	return &data.Data.Attributes, nil
+}
+
+// putKibanaLogView permit to create or update log view
+func putKibanaLogView(c *resty.Client, space string, id string, logView *kibanaLogView) error {
+	path := buildSpacePath(space, basePathKibanaInfraLogViews, id)
+	log.Debugf("URL to put log view: %s", path)
+
+	resp, err := c.R().SetBody(map[string]any{"attributes": logView}).Put(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
+
+// getKibanaMetricsSource permit to get the metrics source configuration. It return nil if not found
+func getKibanaMetricsSource(c *resty.Client, space string, id string) (*kibanaMetricsSource, error) {
+	path := buildSpacePath(space, basePathKibanaInfraMetricsSource, id)
+	log.Debugf("URL to get metrics source: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	data := struct {
+		Source *struct {
+			Configuration kibanaMetricsSource `json:"configuration"`
+		} `json:"source"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return nil, err
+	}
+	if data.Source == nil {
+		return nil, nil
+	}
+
+	return &data.Source.Configuration, nil
+}
+
+// patchKibanaMetricsSource permit to create or update the metrics source configuration
+func patchKibanaMetricsSource(c *resty.Client, space string, id string, metricsSource *kibanaMetricsSource) error {
+	path := buildSpacePath(space, basePathKibanaInfraMetricsSource, id)
+	log.Debugf("URL to patch metrics source: %s", path)
+
+	resp, err := c.R().SetBody(metricsSource).Patch(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// Read the license from Kibana
+// API documentation: not documented, API used by Kibana licensing plugin
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaLicense = "/api/licensing/info" // Base URL to access on license
+)
+
+// kibanaLicense is the license object
+type kibanaLicense struct {
+	UID                string `json:"uid"`
+	Type               string `json:"type"`
+	Mode               string `json:"mode"`
+	Status             string `json:"status"`
+	ExpiryDateInMillis int64  `json:"expiryDateInMillis,omitempty"`
+}
+
+// getKibanaLicense permit to get the license used by Kibana
+func getKibanaLicense(c *resty.Client) (*kibanaLicense, error) {
+	resp, err := c.R().Get(buildPath(basePathKibanaLicense))
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	data := struct {
+		License *kibanaLicense `json:"license"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return nil, err
+	}
+	log.Debug("License: ", data.License)
+
+	return data.License, nil
+}
@@ -0,0 +1,99 @@
+// Call the observability API of Kibana
+// API documentation: https://www.elastic.co/guide/en/observability/current/annotations.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaObservabilityAnnotation = "/api/observability/annotation" // Base URL to access on observability annotations
+)
+
+// kibanaAnnotation is the observability annotation object
+type kibanaAnnotation struct {
+	Timestamp  string                   `json:"@timestamp"`
+	Message    string                   `json:"message"`
+	Annotation kibanaAnnotationType     `json:"annotation"`
+	Tags       []string                 `json:"tags,omitempty"`
+	Service    *kibanaAnnotationService `json:"service,omitempty"`
+}
+
+// kibanaAnnotationType is the type of annotation
+type kibanaAnnotationType struct {
+	Type string `json:"type"`
+}
+
+// kibanaAnnotationService is the service targeted by annotation
+type kibanaAnnotationService struct {
+	Name        string `json:"name,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+// kibanaAnnotationDocument is the annotation stored on Elasticsearch
+type kibanaAnnotationDocument struct {
+	ID     string           `json:"_id"`
+	Index  string           `json:"_index"`
+	Source kibanaAnnotation `json:"_source"`
+}
+
+// createKibanaAnnotation permit to create new annotation
+func createKibanaAnnotation(c *resty.Client, annotation *kibanaAnnotation) (*kibanaAnnotationDocument, error) {
+	resp, err := c.R().SetBody(annotation).Post(buildPath(basePathKibanaObservabilityAnnotation))
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	document := &kibanaAnnotationDocument{}
+	if err = json.Unmarshal(resp.Body(), document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// getKibanaAnnotation permit to get annotation by its ID. It return nil if not found
+func getKibanaAnnotation(c *resty.Client, id string) (*kibanaAnnotationDocument, error) {
+	resp, err := c.R().Get(buildPath(basePathKibanaObservabilityAnnotation, id))
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	document := &kibanaAnnotationDocument{}
+	if err = json.Unmarshal(resp.Body(), document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// deleteKibanaAnnotation permit to delete annotation
+func deleteKibanaAnnotation(c *resty.Client, id string) error {
+	resp, err := c.R().Delete(buildPath(basePathKibanaObservabilityAnnotation, id))
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Call the role management API of Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/role-management-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaRole = "/api/security/role" // Base URL to access on role
+)
+
+// kibanaRole is the role object, with the remote privileges not handled by kbapi.KibanaRole
+type kibanaRole struct {
+	Metadata      map[string]any           `json:"metadata,omitempty"`
+	Elasticsearch *kibanaRoleElasticsearch `json:"elasticsearch,omitempty"`
+	Kibana        []kbapi.KibanaRoleKibana `json:"kibana,omitempty"`
+}
+
+// kibanaRoleElasticsearch is the Elasticsearch privileges of role, with the privileges on remote clusters used by cross-cluster search
+type kibanaRoleElasticsearch struct {
+	kbapi.KibanaRoleElasticsearch
+	RemoteIndices []kibanaRoleRemoteIndice  `json:"remote_indices,omitempty"`
+	RemoteCluster []kibanaRoleRemoteCluster `json:"remote_cluster,omitempty"`
+}
+
+// kibanaRoleRemoteIndice is the privileges on indices of remote clusters
+type kibanaRoleRemoteIndice struct {
+	kbapi.KibanaRoleElasticsearchIndice
+	Clusters []string `json:"clusters"`
+}
+
+// kibanaRoleRemoteCluster is the cluster privileges on remote clusters
+type kibanaRoleRemoteCluster struct {
+	Clusters   []string `json:"clusters"`
+	Privileges []string `json:"privileges"`
+}
+
+// getKibanaRole permit to get role. It return nil when role not exist
+func getKibanaRole(c *resty.Client, name string) (*kibanaRole, error) {
+	path := buildPath(basePathKibanaRole, name)
+	log.Debugf("URL to get role: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	role := &kibanaRole{}
+	if err = json.Unmarshal(resp.Body(), role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// putKibanaRole permit to create or update role
+func putKibanaRole(c *resty.Client, name string, role *kibanaRole) error {
+	path := buildPath(basePathKibanaRole, name)
+	log.Debugf("URL to put role: %s", path)
+
+	resp, err := c.R().SetBody(role).Put(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Call the saved objects API of Kibana not covered by go-kibana-rest
+// API documentation: https://www.elastic.co/guide/en/kibana/master/saved-objects-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaSavedObjectResolve = "/api/saved_objects/resolve" // Base URL to resolve saved object, following aliases
+	basePathKibanaSavedObjectExport  = "/api/saved_objects/_export" // Base URL to export saved objects
+	basePathKibanaDashboardApp       = "/app/dashboards#/view"      // URL of dashboard page on Kibana UI
+)
+
+// kibanaSavedObjectResolution is the result of saved object resolution
+type kibanaSavedObjectResolution struct {
+	SavedObject struct {
+		ID         string         `json:"id"`
+		Type       string         `json:"type"`
+		Attributes map[string]any `json:"attributes"`
+	} `json:"saved_object"`
+	Outcome       string `json:"outcome"`
+	AliasTargetID string `json:"alias_target_id"`
+	AliasPurpose  string `json:"alias_purpose"`
+}
+
+// kibanaSavedObjectReference is the reference to one saved object
+type kibanaSavedObjectReference struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// resolveKibanaSavedObject permit to resolve saved object by its ID or legacy ID. It return nil if not found
+func resolveKibanaSavedObject(c *resty.Client, space string, objectType string, id string) (*kibanaSavedObjectResolution, error) {
+	path := buildSpacePath(space, basePathKibanaSavedObjectResolve, objectType, id)
+	log.Debugf("URL to resolve saved object: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	resolution := &kibanaSavedObjectResolution{}
+	if err = json.Unmarshal(resp.Body(), resolution); err != nil {
+		return nil, err
+	}
+
+	return resolution, nil
+}
+
+// exportKibanaSavedObjectsByReferences permit to export, as NDJSON, the saved objects of types that reference one of the given objects, like tags.
+// The export details line is not added
+func exportKibanaSavedObjectsByReferences(c *resty.Client, space string, types []string, references []kibanaSavedObjectReference, deep bool) ([]byte, error) {
+	path := buildSpacePath(space, basePathKibanaSavedObjectExport)
+	log.Debugf("URL to export saved objects: %s", path)
+
+	resp, err := c.R().
+		SetBody(map[string]any{
+			"type":                  types,
+			"hasReference":          references,
+			"includeReferencesDeep": deep,
+			"excludeExportDetails":  true,
+		}).
+		Post(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+
+	return resp.Body(), nil
+}
@@ -0,0 +1,50 @@
+// Read the authenticated user from Kibana
+// API documentation: not documented, internal API used by Kibana UI
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaSecurityMe = "/internal/security/me" // Base URL to access on the current user
+)
+
+// kibanaCurrentUser is the authenticated user object
+type kibanaCurrentUser struct {
+	Username string   `json:"username"`
+	FullName string   `json:"full_name,omitempty"`
+	Email    string   `json:"email,omitempty"`
+	Roles    []string `json:"roles"`
+}
+
+// getKibanaCurrentUser permit to get the user used to call Kibana API
+func getKibanaCurrentUser(c *resty.Client) (*kibanaCurrentUser, error) {
+	resp, err := c.R().
+		SetHeader("x-elastic-internal-origin", "kibana").
+		Get(buildPath(basePathKibanaSecurityMe))
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	user := &kibanaCurrentUser{}
+	if err = json.Unmarshal(resp.Body(), user); err != nil {
+		return nil, err
+	}
+	log.Debug("User: ", user)
+
+	return user, nil
+}
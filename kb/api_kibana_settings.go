@@ -0,0 +1,53 @@
+// Read the advanced settings of Kibana space
+// API documentation: not documented, API used by Kibana UI
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaSettings = "/api/kibana/settings" // Base URL to access on advanced settings
+)
+
+// getKibanaSettings permit to get the advanced settings changed by user on space
+func getKibanaSettings(c *resty.Client, space string) (map[string]any, error) {
+	path := buildSpacePath(space, basePathKibanaSettings)
+	log.Debugf("URL to get settings: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	data := struct {
+		Settings map[string]struct {
+			UserValue any `json:"userValue"`
+		} `json:"settings"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]any, len(data.Settings))
+	for key, setting := range data.Settings {
+		// buildNum is internal setting that change on each upgrade
+		if key == "buildNum" {
+			continue
+		}
+		settings[key] = setting.UserValue
+	}
+	log.Debug("Settings: ", settings)
+
+	return settings, nil
+}
@@ -0,0 +1,104 @@
+// Call the spaces API of Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/spaces-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaSpace = "/api/spaces/space" // Base URL to access on user spaces
+)
+
+// kibanaSpace is the user space object, with the avatar image not handled by kbapi.KibanaSpace.
+// Update replace the whole space, so the avatar must be read and sent back to be kept
+type kibanaSpace struct {
+	kbapi.KibanaSpace
+	ImageURL string `json:"imageUrl,omitempty"`
+}
+
+// listKibanaSpaces permit to get all user spaces
+func listKibanaSpaces(c *resty.Client) ([]kibanaSpace, error) {
+	path := buildPath(basePathKibanaSpace)
+	log.Debugf("URL to list user spaces: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	spaces := make([]kibanaSpace, 0)
+	if err = json.Unmarshal(resp.Body(), &spaces); err != nil {
+		return nil, err
+	}
+
+	return spaces, nil
+}
+
+// getKibanaSpace permit to get user space. It return nil when user space not exist
+func getKibanaSpace(c *resty.Client, id string) (*kibanaSpace, error) {
+	path := buildPath(basePathKibanaSpace, id)
+	log.Debugf("URL to get user space: %s", path)
+
+	resp, err := c.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	space := &kibanaSpace{}
+	if err = json.Unmarshal(resp.Body(), space); err != nil {
+		return nil, err
+	}
+
+	return space, nil
+}
+
+// createKibanaSpace permit to create user space
+func createKibanaSpace(c *resty.Client, space *kibanaSpace) error {
+	path := buildPath(basePathKibanaSpace)
+	log.Debugf("URL to create user space: %s", path)
+
+	resp, err := c.R().SetBody(space).Post(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+
+	return nil
+}
+
+// updateKibanaSpace permit to update user space. All fields are replaced
+func updateKibanaSpace(c *resty.Client, space *kibanaSpace) error {
+	path := buildPath(basePathKibanaSpace, space.ID)
+	log.Debugf("URL to update user space: %s", path)
+
+	resp, err := c.R().SetBody(space).Put(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), "%s: %s", resp.Status(), resp.Body())
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+// Call the timeline API of Kibana
+// API documentation: https://www.elastic.co/guide/en/security/current/timeline-api-overview.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	basePathKibanaTimeline = "/api/timeline" // Base URL to access on timelines and timeline templates
+)
+
+// getKibanaTimelineByTemplateID permit to get the saved object ID of timeline template. It return empty string if not found
+func getKibanaTimelineByTemplateID(c *resty.Client, space string, templateTimelineID string) (string, error) {
+	path := buildSpacePath(space, basePathKibanaTimeline)
+	log.Debugf("URL to get timeline template: %s", path)
+
+	resp, err := c.R().
+		SetQueryParam("template_timeline_id", templateTimelineID).
+		Get(path)
+	if err != nil {
+		return "", err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return "", nil
+		}
+		return "", kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	data := struct {
+		Data struct {
+			GetOneTimeline *struct {
+				SavedObjectID string `json:"savedObjectId"`
+			} `json:"getOneTimeline"`
+		} `json:"data"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &data); err != nil {
+		return "", err
+	}
+	if data.Data.GetOneTimeline == nil {
+		return "", nil
+	}
+
+	return data.Data.GetOneTimeline.SavedObjectID, nil
+}
+
+// exportKibanaTimeline permit to export timeline as JSON. It return empty string if not found
+func exportKibanaTimeline(c *resty.Client, space string, id string) (string, error) {
+	path := buildSpacePath(space, basePathKibanaTimeline, "_export")
+	log.Debugf("URL to export timeline: %s", path)
+
+	resp, err := c.R().
+		SetQueryParam("file_name", "timelines_export.ndjson").
+		SetBody(map[string]any{"ids": []string{id}}).
+		Post(path)
+	if err != nil {
+		return "", err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		if resp.StatusCode() == 404 {
+			return "", nil
+		}
+		return "", kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	lines := splitNDJSON(string(resp.Body()))
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	return strings.TrimSpace(lines[0]), nil
+}
+
+// importKibanaTimeline permit to import timeline or timeline template from JSON
+func importKibanaTimeline(c *resty.Client, space string, data string) error {
+	path := buildSpacePath(space, basePathKibanaTimeline, "_import")
+	log.Debugf("URL to import timeline: %s", path)
+
+	resp, err := c.R().
+		SetFileReader("file", "timelines_import.ndjson", bytes.NewReader([]byte(data))).
+		Post(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+	result := struct {
+		Success bool `json:"success"`
+		Errors  []struct {
+			ID    string `json:"id"`
+			Error struct {
+				Message    string `json:"message"`
+				StatusCode int    `json:"status_code"`
+			} `json:"error"`
+		} `json:"errors"`
+	}{}
+	if err = json.Unmarshal(resp.Body(), &result); err != nil {
+		return err
+	}
+	if !result.Success {
+		errs := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			errs = append(errs, fmt.Sprintf("%s: %s", e.ID, e.Error.Message))
+		}
+		return kbapi.NewAPIError(resp.StatusCode(), "Error when import timeline: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// deleteKibanaTimeline permit to delete timeline or timeline template
+func deleteKibanaTimeline(c *resty.Client, space string, id string) error {
+	path := buildSpacePath(space, basePathKibanaTimeline)
+	log.Debugf("URL to delete timeline: %s", path)
+
+	resp, err := c.R().
+		SetBody(map[string]any{"savedObjectIds": []string{id}}).
+		Delete(path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Response: ", resp)
+	if resp.StatusCode() >= 300 {
+		return kbapi.NewAPIError(resp.StatusCode(), resp.Status())
+	}
+
+	return nil
+}
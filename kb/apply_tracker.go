@@ -0,0 +1,321 @@
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// applyOperation is one Create, Update or Delete call done by the provider
+type applyOperation struct {
+	resourceType string
+	id           string
+	operation    string
+	err          string
+	dryRun       bool
+	duration     time.Duration
+}
+
+// applyMetric is the count and latencies of one operation on one resource type
+type applyMetric struct {
+	ResourceType string  `json:"resource_type"`
+	Operation    string  `json:"operation"`
+	Count        int     `json:"count"`
+	Errors       int     `json:"errors"`
+	TotalMs      float64 `json:"total_ms"`
+	AvgMs        float64 `json:"avg_ms"`
+	MaxMs        float64 `json:"max_ms"`
+}
+
+// defaultDryRunSummaryWindow is the time without new operation skipped by dry run mode, before giving the summary of planned operations
+const defaultDryRunSummaryWindow = 500 * time.Millisecond
+
+// applyTracker keep all operations done during the current apply.
+// When an operation failed, the summary is added on diagnostics so operators can reconcile quickly.
+type applyTracker struct {
+	mutex        sync.Mutex
+	operations   []applyOperation
+	metricsFile  string
+	dryRunWindow time.Duration
+	nbDryRun     int
+}
+
+// applyOperationKey is the context key of the operation recorded by tracker, so wrapped CRUD functions can change it
+type applyOperationKey struct{}
+
+// newApplyTracker return new apply tracker
+func newApplyTracker() *applyTracker {
+	return &applyTracker{
+		operations:   make([]applyOperation, 0),
+		dryRunWindow: defaultDryRunSummaryWindow,
+	}
+}
+
+// setTrackedOperation permit to change the operation recorded by tracker, like a delete that only remove resource from state
+func setTrackedOperation(ctx context.Context, operation string) {
+	if op, ok := ctx.Value(applyOperationKey{}).(*string); ok {
+		*op = operation
+	}
+}
+
+// record permit to add operation on tracker
+func (t *applyTracker) record(resourceType string, id string, operation string, duration time.Duration, diags diag.Diagnostics) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	op := applyOperation{
+		resourceType: resourceType,
+		id:           id,
+		operation:    operation,
+		duration:     duration,
+	}
+	if diags.HasError() {
+		errs := make([]string, 0, len(diags))
+		for _, d := range diags {
+			if d.Severity == diag.Error {
+				errs = append(errs, d.Summary)
+			}
+		}
+		op.err = strings.Join(errs, ", ")
+	}
+
+	t.operations = append(t.operations, op)
+}
+
+// recordDryRun permit to add operation skipped by dry run mode on tracker
+func (t *applyTracker) recordDryRun(resourceType string, id string, operation string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.operations = append(t.operations, applyOperation{
+		resourceType: resourceType,
+		id:           id,
+		operation:    operation,
+		dryRun:       true,
+	})
+	t.nbDryRun++
+}
+
+// waitDryRunSummary permit to wait the end of operations skipped by dry run mode.
+// Terraform call them in parallel, and not call them that depend on skipped operations, so the run end when no operation is skipped during the window.
+// It return the summary of planned operations for the last skipped operation, and empty string for the others
+func (t *applyTracker) waitDryRunSummary() string {
+	t.mutex.Lock()
+	nbDryRun := t.nbDryRun
+	t.mutex.Unlock()
+
+	time.Sleep(t.dryRunWindow)
+
+	t.mutex.Lock()
+	last := t.nbDryRun == nbDryRun
+	t.mutex.Unlock()
+	if !last {
+		return ""
+	}
+
+	return t.summary()
+}
+
+// summary return the list of operations, grouped by created, updated, deleted, forgotten (only removed from state), failed and planned (dry run)
+func (t *applyTracker) summary() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	groups := map[string][]string{}
+	for _, op := range t.operations {
+		if op.dryRun {
+			groups["planned"] = append(groups["planned"], fmt.Sprintf("%s %s (%s)", op.resourceType, op.id, op.operation))
+		} else if op.err != "" {
+			groups["failed"] = append(groups["failed"], fmt.Sprintf("%s %s (%s): %s", op.resourceType, op.id, op.operation, op.err))
+		} else {
+			groups[op.operation] = append(groups[op.operation], fmt.Sprintf("%s %s", op.resourceType, op.id))
+		}
+	}
+
+	var sb strings.Builder
+	for _, group := range []string{"created", "updated", "deleted", "forgotten", "failed", "planned"} {
+		items := groups[group]
+		if (group == "forgotten" || group == "planned") && len(items) == 0 {
+			continue
+		}
+		sort.Strings(items)
+		sb.WriteString(fmt.Sprintf("%s (%d):\n", group, len(items)))
+		for _, item := range items {
+			sb.WriteString(fmt.Sprintf("  - %s\n", item))
+		}
+	}
+
+	return sb.String()
+}
+
+// metrics return the count and latencies of operations, grouped by resource type and operation.
+// Operations skipped by dry run mode are not counted. The caller must hold the mutex.
+func (t *applyTracker) metrics() []applyMetric {
+	metrics := make([]applyMetric, 0)
+	indexes := map[string]int{}
+	for _, op := range t.operations {
+		if op.dryRun {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s", op.resourceType, op.operation)
+		i, ok := indexes[key]
+		if !ok {
+			metrics = append(metrics, applyMetric{
+				ResourceType: op.resourceType,
+				Operation:    op.operation,
+			})
+			i = len(metrics) - 1
+			indexes[key] = i
+		}
+
+		durationMs := float64(op.duration) / float64(time.Millisecond)
+		metrics[i].Count++
+		if op.err != "" {
+			metrics[i].Errors++
+		}
+		metrics[i].TotalMs += durationMs
+		if durationMs > metrics[i].MaxMs {
+			metrics[i].MaxMs = durationMs
+		}
+		metrics[i].AvgMs = metrics[i].TotalMs / float64(metrics[i].Count)
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].ResourceType != metrics[j].ResourceType {
+			return metrics[i].ResourceType < metrics[j].ResourceType
+		}
+		return metrics[i].Operation < metrics[j].Operation
+	})
+
+	return metrics
+}
+
+// writeMetrics permit to write the metrics on the metrics file.
+// The file is written after each operation, so at the end of apply it contain all operations.
+// Operations run in parallel, so the mutex is held until the file is replaced, and it's replaced atomically
+// to never let a partial file.
+func (t *applyTracker) writeMetrics() error {
+	if t.metricsFile == "" {
+		return nil
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	b, err := json.MarshalIndent(map[string]any{"operations": t.metrics()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(t.metricsFile), filepath.Base(t.metricsFile)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err = tmpFile.Write(b); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), t.metricsFile)
+}
+
+// trackResource wrap the Create, Update and Delete functions of resource to record them on apply tracker
+func trackResource(resourceType string, r *schema.Resource) *schema.Resource {
+	r.CreateContext = trackOperation(resourceType, "created", r.CreateContext)
+	r.UpdateContext = trackOperation(resourceType, "updated", r.UpdateContext)
+	r.DeleteContext = trackOperation(resourceType, "deleted", r.DeleteContext)
+
+	return r
+}
+
+// trackOperation wrap CRUD function to record it on apply tracker.
+// In dry run mode, the CRUD function is not called and the operation failed, without changing the state.
+// The summary of planned operations is given once, on the last skipped operation.
+func trackOperation(resourceType string, operation string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		id := resourceIdentifier(d)
+		tracker := meta.(*kibanaMeta).tracker
+
+		if meta.(*kibanaMeta).dryRun {
+			log.Infof("Dry run: %s %s not %s", resourceType, id, operation)
+			fmt.Printf("[INFO] Dry run: %s %s not %s", resourceType, id, operation)
+			tracker.recordDryRun(resourceType, id, operation)
+
+			// Keep the previous state on update, else the planned values are saved on state
+			d.Partial(true)
+
+			diags := diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Dry run: %s %s not %s", resourceType, id, operation),
+			}}
+			if summary := tracker.waitDryRunSummary(); summary != "" {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Summary of Kibana objects planned by this dry run",
+					Detail:   summary,
+				})
+			}
+
+			return diags
+		}
+
+		trackedOperation := operation
+		start := time.Now()
+		diags := f(context.WithValue(ctx, applyOperationKey{}, &trackedOperation), d, meta)
+		if d.Id() != "" {
+			id = d.Id()
+		}
+
+		tracker.record(resourceType, id, trackedOperation, time.Since(start), diags)
+		if err := tracker.writeMetrics(); err != nil {
+			log.Warnf("Can't write metrics file %s: %s", tracker.metricsFile, err.Error())
+		}
+
+		if diags.HasError() {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Summary of Kibana objects changed by this apply",
+				Detail:   tracker.summary(),
+			})
+		}
+
+		return diags
+	}
+}
+
+// resourceIdentifier return the best identifier of resource, even if it's not yet created
+func resourceIdentifier(d *schema.ResourceData) string {
+	if d.Id() != "" {
+		return d.Id()
+	}
+	for _, key := range []string{"name", "uid"} {
+		if value, ok := d.GetOk(key); ok {
+			if id, ok := value.(string); ok {
+				return id
+			}
+		}
+	}
+
+	return "<unknown>"
+}
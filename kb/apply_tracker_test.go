@@ -0,0 +1,254 @@
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestApplyTracker(t *testing.T) {
+	meta := &kibanaMeta{
+		tracker: newApplyTracker(),
+	}
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if d.Get("name").(string) == "bad" {
+				return diag.FromErr(errors.New("boom"))
+			}
+			d.SetId(d.Get("name").(string))
+			return nil
+		},
+	}
+	trackResource("kibana_test", r)
+
+	d := r.TestResourceData()
+	_ = d.Set("name", "good")
+	if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("err: %+v", diags)
+	}
+
+	d = r.TestResourceData()
+	_ = d.Set("name", "bad")
+	diags := r.CreateContext(context.Background(), d, meta)
+	if !diags.HasError() {
+		t.Fatal("Expected error")
+	}
+	if len(diags) != 2 || diags[1].Severity != diag.Warning {
+		t.Fatalf("Expected error and summary, got %+v", diags)
+	}
+
+	summary := diags[1].Detail
+	if !strings.Contains(summary, "created (1):\n  - kibana_test good\n") {
+		t.Errorf("Expected created object on summary, got:\n%s", summary)
+	}
+	if !strings.Contains(summary, "failed (1):\n  - kibana_test bad (created): boom\n") {
+		t.Errorf("Expected failed object on summary, got:\n%s", summary)
+	}
+}
+
+func TestApplyTrackerDryRun(t *testing.T) {
+	meta := &kibanaMeta{
+		tracker: newApplyTracker(),
+		dryRun:  true,
+	}
+	meta.tracker.dryRunWindow = 50 * time.Millisecond
+
+	nbCalls := 0
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			nbCalls++
+			d.SetId(d.Get("name").(string))
+			return nil
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			nbCalls++
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+	}
+	trackResource("kibana_test", r)
+
+	// Operations run in parallel, only the last one give the summary
+	results := make(chan diag.Diagnostics, 3)
+	var wg sync.WaitGroup
+	for _, name := range []string{"foo", "bar"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			d := r.TestResourceData()
+			_ = d.Set("name", name)
+			results <- r.CreateContext(context.Background(), d, meta)
+			if d.Id() != "" {
+				t.Errorf("Expected no ID, got %s", d.Id())
+			}
+		}(name)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		state := &terraform.InstanceState{
+			ID:         "baz",
+			Attributes: map[string]string{"id": "baz", "name": "baz", "description": "old"},
+		}
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{"name": "baz", "description": "new"}), meta)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		newState, diags := r.Apply(context.Background(), state, diff, meta)
+		results <- diags
+		// Planned values are not saved on state
+		if newState == nil || newState.Attributes["description"] != "old" {
+			t.Errorf("Expected state kept as is, got %+v", newState)
+		}
+	}()
+	wg.Wait()
+	close(results)
+
+	if nbCalls != 0 {
+		t.Errorf("Expected CRUD functions not called, got %d calls", nbCalls)
+	}
+	summaries := make([]string, 0)
+	for diags := range results {
+		if !diags.HasError() {
+			t.Errorf("Expected error, got %+v", diags)
+		}
+		for _, d := range diags {
+			if d.Severity == diag.Warning {
+				summaries = append(summaries, d.Detail)
+			}
+		}
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("Expected one summary, got %d", len(summaries))
+	}
+	if !strings.Contains(summaries[0], "planned (3):\n  - kibana_test bar (created)\n  - kibana_test baz (updated)\n  - kibana_test foo (created)\n") {
+		t.Errorf("Expected planned objects on summary, got:\n%s", summaries[0])
+	}
+}
+
+func TestApplyTrackerForgotten(t *testing.T) {
+	meta := &kibanaMeta{
+		tracker:        newApplyTracker(),
+		protectedTypes: map[string]bool{"space": true},
+	}
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			t.Error("Expected protected delete not called")
+			return nil
+		},
+	}
+	trackResource("kibana_user_space", protectResource("kibana_user_space", r))
+
+	d := r.TestResourceData()
+	d.SetId("team-a")
+	if diags := r.DeleteContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("err: %+v", diags)
+	}
+
+	summary := meta.tracker.summary()
+	if !strings.Contains(summary, "deleted (0):\n") || !strings.Contains(summary, "forgotten (1):\n  - kibana_user_space team-a\n") {
+		t.Errorf("Expected forgotten object on summary, got:\n%s", summary)
+	}
+}
+
+func TestApplyTrackerMetrics(t *testing.T) {
+	tracker := newApplyTracker()
+	tracker.metricsFile = filepath.Join(t.TempDir(), "metrics.json")
+
+	tracker.record("kibana_role", "foo", "created", 10*time.Millisecond, nil)
+	tracker.record("kibana_role", "bar", "created", 30*time.Millisecond, diag.FromErr(errors.New("boom")))
+	tracker.record("kibana_object", "baz", "updated", 5*time.Millisecond, nil)
+	tracker.recordDryRun("kibana_role", "qux", "deleted")
+
+	if err := tracker.writeMetrics(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(tracker.metricsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string][]applyMetric{}
+	if err = json.Unmarshal(b, &data); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []applyMetric{
+		{ResourceType: "kibana_object", Operation: "updated", Count: 1, TotalMs: 5, AvgMs: 5, MaxMs: 5},
+		{ResourceType: "kibana_role", Operation: "created", Count: 2, Errors: 1, TotalMs: 40, AvgMs: 20, MaxMs: 30},
+	}
+	if !reflect.DeepEqual(data["operations"], expected) {
+		t.Errorf("Expected %+v, got %+v", expected, data["operations"])
+	}
+}
+
+func TestApplyTrackerMetricsConcurrent(t *testing.T) {
+	tracker := newApplyTracker()
+	tracker.metricsFile = filepath.Join(t.TempDir(), "metrics.json")
+
+	// Terraform run operations in parallel, and each of them write the metrics file
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tracker.record("kibana_role", fmt.Sprintf("role-%d", i), "created", time.Millisecond, nil)
+			if err := tracker.writeMetrics(); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	b, err := os.ReadFile(tracker.metricsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string][]applyMetric{}
+	if err = json.Unmarshal(b, &data); err != nil {
+		t.Fatalf("Expected valid metrics file, got %s: %s", err, string(b))
+	}
+	if len(data["operations"]) != 1 || data["operations"][0].Count != 20 {
+		t.Errorf("Expected last written metrics with all operations, got %+v", data["operations"])
+	}
+	files, err := os.ReadDir(filepath.Dir(tracker.metricsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected temporary files removed, got %d files", len(files))
+	}
+}
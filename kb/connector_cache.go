@@ -0,0 +1,107 @@
+package kb
+
+import (
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// kibanaConnectorCache keep the connectors of each space listed during the current run.
+// It permit to reference connectors by name on many resources with only one list call by space
+type kibanaConnectorCache struct {
+	mutex      sync.Mutex
+	candidates map[string][]kibanaReferenceCandidate
+	types      map[string]map[string]string
+}
+
+// newKibanaConnectorCache return new empty connector cache
+func newKibanaConnectorCache() *kibanaConnectorCache {
+	return &kibanaConnectorCache{
+		candidates: map[string][]kibanaReferenceCandidate{},
+		types:      map[string]map[string]string{},
+	}
+}
+
+// resolve return the connector ID of each name in space.
+// Connectors are listed again once when a name is not found, in case the connector is created after the cache was filled
+func (c *kibanaConnectorCache) resolve(client *resty.Client, space string, names []string) (map[string]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	candidates, isCached := c.candidates[space]
+	if !isCached {
+		if err := c.refresh(client, space); err != nil {
+			return nil, err
+		}
+		candidates = c.candidates[space]
+	}
+
+	ids, err := matchKibanaReferences("connector", names, candidates)
+	if err != nil && isCached {
+		log.Debugf("Refresh connectors of space %s: %s", space, err.Error())
+		if err = c.refresh(client, space); err != nil {
+			return nil, err
+		}
+		return matchKibanaReferences("connector", names, c.candidates[space])
+	}
+
+	return ids, err
+}
+
+// connectorTypes return the connector type of each connector ID in space.
+// Connectors are listed again once when an ID is not found, and unknown IDs have empty type
+func (c *kibanaConnectorCache) connectorTypes(client *resty.Client, space string, ids []string) (map[string]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	types, isCached := c.types[space]
+	if !isCached {
+		if err := c.refresh(client, space); err != nil {
+			return nil, err
+		}
+		types = c.types[space]
+	}
+
+	connectorTypes := make(map[string]string, len(ids))
+	for _, id := range ids {
+		connectorType, ok := types[id]
+		if !ok && isCached {
+			log.Debugf("Refresh connectors of space %s: connector %s not found", space, id)
+			if err := c.refresh(client, space); err != nil {
+				return nil, err
+			}
+			types = c.types[space]
+			isCached = false
+			connectorType = types[id]
+		}
+		connectorTypes[id] = connectorType
+	}
+
+	return connectorTypes, nil
+}
+
+// refresh permit to list the connectors of space in cache
+func (c *kibanaConnectorCache) refresh(client *resty.Client, space string) error {
+	connectors, err := listKibanaConnectors(client, space)
+	if err != nil {
+		return err
+	}
+	c.candidates[space] = newKibanaConnectorCandidates(connectors)
+	c.types[space] = make(map[string]string, len(connectors))
+	for _, connector := range connectors {
+		c.types[space][connector.ID] = connector.ConnectorTypeID
+	}
+
+	return nil
+}
+
+// newKibanaConnectorCandidates return the connectors that can match a connector name
+func newKibanaConnectorCandidates(connectors []kibanaConnector) []kibanaReferenceCandidate {
+	candidates := make([]kibanaReferenceCandidate, 0, len(connectors))
+	for _, connector := range connectors {
+		candidates = append(candidates, kibanaReferenceCandidate{ID: connector.ID, Names: []string{connector.Name}})
+	}
+
+	return candidates
+}
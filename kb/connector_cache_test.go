@@ -0,0 +1,49 @@
+package kb
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaConnectorCache(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":                 "http://kibana.mock:5601",
+		"mock_endpoints_file": path + "/../fixtures/mock-endpoints.json",
+	}))
+	if diags.HasError() {
+		t.Fatalf("err: %+v", diags)
+	}
+	meta := provider.Meta().(*kibanaMeta)
+
+	ids, err := meta.connectors.resolve(meta.client.Client, "default", []string{"Slack SRE", "Elastic-Cloud-SMTP"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids["Slack SRE"] != "slack-sre" || ids["Elastic-Cloud-SMTP"] != "elastic-cloud-email" {
+		t.Errorf("Unexpected connector IDs: %+v", ids)
+	}
+	if len(meta.connectors.candidates["default"]) != 2 {
+		t.Errorf("Expected connectors of space default in cache, got %+v", meta.connectors.candidates)
+	}
+
+	if _, err = meta.connectors.resolve(meta.client.Client, "default", []string{"Missing"}); err == nil {
+		t.Error("Expected error when connector not found")
+	}
+
+	types, err := meta.connectors.connectorTypes(meta.client.Client, "default", []string{"slack-sre", "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if types["slack-sre"] != ".slack" || types["missing"] != "" {
+		t.Errorf("Unexpected connector types: %+v", types)
+	}
+}
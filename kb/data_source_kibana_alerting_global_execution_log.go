@@ -0,0 +1,294 @@
+// Return the execution logs of all alerting rules in space
+// API documentation: not documented, internal API used by Kibana UI
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+func dataSourceKibanaAlertingGlobalExecutionLog() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_alerting_global_execution_log` can be used to retrieve the execution logs of all alerting rules in space.",
+		ReadContext: dataSourceKibanaAlertingGlobalExecutionLogRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space where rules run",
+			},
+			"date_start": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The start date of the range, as ISO 8601 date",
+			},
+			"date_end": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The end date of the range, as ISO 8601 date. Default to now",
+			},
+			"outcomes": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Keep only executions with this outcomes",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"success", "failure", "warning", "unknown"}, false),
+				},
+			},
+			"filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "KQL filter on execution logs",
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 10000),
+				Description:  "The maximum number of executions to return",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of executions that match filters",
+			},
+			"kpi": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The summary of executions that match filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"success": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"failure": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"warning": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"unknown": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"active_alerts": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"new_alerts": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"recovered_alerts": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"triggered_actions": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"errored_actions": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"executions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The executions that match filters, the newest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"duration_ms": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"timed_out": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"num_active_alerts": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"num_new_alerts": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"num_recovered_alerts": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"num_triggered_actions": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"num_errored_actions": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKibanaAlertingGlobalExecutionLogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+	dateStart := d.Get("date_start").(string)
+	dateEnd := d.Get("date_end").(string)
+	outcomes := convertArrayInterfaceToArrayString(d.Get("outcomes").(*schema.Set).List())
+	filter := d.Get("filter").(string)
+	maxResults := d.Get("max_results").(int)
+
+	client := m.(*kibanaMeta).client
+
+	parameters := &kibanaExecutionLogParameters{
+		DateStart: dateStart,
+		DateEnd:   dateEnd,
+		Filter:    buildExecutionLogFilter(outcomes, filter),
+	}
+	log.Debugf("Execution log parameters: %+v", parameters)
+
+	total := 0
+	perPage := defaultPerPage
+	if maxResults < perPage {
+		perPage = maxResults
+	}
+	executionLogs, err := listAllPages(perPage, func(page int, perPage int) ([]kibanaExecutionLog, int, error) {
+		executionLogs, err := getKibanaGlobalExecutionLogs(client.Client, space, parameters, page, perPage)
+		if err != nil {
+			return nil, 0, err
+		}
+		total = executionLogs.Total
+		if total > maxResults {
+			return executionLogs.Data, maxResults, nil
+		}
+		return executionLogs.Data, total, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(executionLogs) > maxResults {
+		executionLogs = executionLogs[:maxResults]
+	}
+
+	executionKPI, err := getKibanaGlobalExecutionKPI(client.Client, space, parameters)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", space, dateStart, dateEnd))
+	if err = d.Set("total", total); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("kpi", flattenKibanaExecutionKPI(executionKPI)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("executions", flattenKibanaExecutionLogs(executionLogs)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read %d execution logs successfully", len(executionLogs))
+
+	return nil
+}
+
+// buildExecutionLogFilter permit to build the KQL filter from outcomes and custom filter
+func buildExecutionLogFilter(outcomes []string, filter string) string {
+	filters := make([]string, 0, 2)
+	if len(outcomes) > 0 {
+		filters = append(filters, fmt.Sprintf("kibana.alerting.outcome:(%s)", strings.Join(outcomes, " or ")))
+	}
+	if filter != "" {
+		filters = append(filters, fmt.Sprintf("(%s)", filter))
+	}
+
+	return strings.Join(filters, " and ")
+}
+
+func flattenKibanaExecutionKPI(executionKPI *kibanaExecutionKPI) []interface{} {
+	if executionKPI == nil {
+		return nil
+	}
+
+	tfMap := map[string]interface{}{
+		"success":           executionKPI.Success,
+		"failure":           executionKPI.Failure,
+		"warning":           executionKPI.Warning,
+		"unknown":           executionKPI.Unknown,
+		"active_alerts":     executionKPI.ActiveAlerts,
+		"new_alerts":        executionKPI.NewAlerts,
+		"recovered_alerts":  executionKPI.RecoveredAlerts,
+		"triggered_actions": executionKPI.TriggeredActions,
+		"errored_actions":   executionKPI.ErroredActions,
+	}
+
+	return []interface{}{tfMap}
+}
+
+func flattenKibanaExecutionLogs(executionLogs []kibanaExecutionLog) []interface{} {
+	tfList := make([]interface{}, 0, len(executionLogs))
+
+	for _, executionLog := range executionLogs {
+		tfList = append(tfList, map[string]interface{}{
+			"id":                    executionLog.ID,
+			"rule_id":               executionLog.RuleID,
+			"rule_name":             executionLog.RuleName,
+			"timestamp":             executionLog.Timestamp,
+			"status":                executionLog.Status,
+			"message":               executionLog.Message,
+			"duration_ms":           executionLog.DurationMs,
+			"timed_out":             executionLog.TimedOut,
+			"num_active_alerts":     executionLog.NumActiveAlerts,
+			"num_new_alerts":        executionLog.NumNewAlerts,
+			"num_recovered_alerts":  executionLog.NumRecoveredAlerts,
+			"num_triggered_actions": executionLog.NumTriggeredActions,
+			"num_errored_actions":   executionLog.NumErroredActions,
+		})
+	}
+
+	return tfList
+}
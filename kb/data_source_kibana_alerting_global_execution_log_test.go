@@ -0,0 +1,43 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaAlertingGlobalExecutionLog(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaAlertingGlobalExecutionLog,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kibana_alerting_global_execution_log.test", "total"),
+					resource.TestCheckResourceAttr("data.kibana_alerting_global_execution_log.test", "kpi.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestBuildExecutionLogFilter(t *testing.T) {
+	if filter := buildExecutionLogFilter(nil, ""); filter != "" {
+		t.Errorf("Expected empty filter, got %s", filter)
+	}
+
+	expected := "kibana.alerting.outcome:(failure or warning) and (rule.name: test)"
+	if filter := buildExecutionLogFilter([]string{"failure", "warning"}, "rule.name: test"); filter != expected {
+		t.Errorf("Expected %s, got %s", expected, filter)
+	}
+}
+
+var testDataSourceKibanaAlertingGlobalExecutionLog = `
+data "kibana_alerting_global_execution_log" "test" {
+  date_start = "2022-01-01T00:00:00Z"
+  outcomes   = ["failure"]
+}
+`
@@ -0,0 +1,127 @@
+// Return the connector types available in Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/list-connector-types-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+func dataSourceKibanaConnectorTypes() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_connector_types` can be used to retrieve the connector types available in Kibana, with the features they support.",
+		ReadContext: dataSourceKibanaConnectorTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space where to list connector types",
+			},
+			"feature_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"alerting", "cases", "uptime", "siem", "generativeAI"}, false),
+				Description:  "Keep only connector types that support this feature",
+			},
+			"connector_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The connector types, sorted by ID",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"enabled_in_config": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"enabled_in_license": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"minimum_license_required": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"supported_feature_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"is_system_action_type": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKibanaConnectorTypesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+	featureID := d.Get("feature_id").(string)
+
+	client := m.(*kibanaMeta).client
+
+	connectorTypes, err := listKibanaConnectorTypes(client.Client, space, featureID)
+	if err != nil {
+		return handleAPIError(err, "list connector types")
+	}
+	sort.Slice(connectorTypes, func(i, j int) bool {
+		return connectorTypes[i].ID < connectorTypes[j].ID
+	})
+
+	d.SetId(fmt.Sprintf("%s/%s", space, featureID))
+	if err = d.Set("connector_types", flattenKibanaConnectorTypes(connectorTypes)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read %d connector types successfully", len(connectorTypes))
+
+	return nil
+}
+
+func flattenKibanaConnectorTypes(connectorTypes []kibanaConnectorType) []interface{} {
+	tfList := make([]interface{}, 0, len(connectorTypes))
+
+	for _, connectorType := range connectorTypes {
+		tfList = append(tfList, map[string]interface{}{
+			"id":                       connectorType.ID,
+			"name":                     connectorType.Name,
+			"enabled":                  connectorType.Enabled,
+			"enabled_in_config":        connectorType.EnabledInConfig,
+			"enabled_in_license":       connectorType.EnabledInLicense,
+			"minimum_license_required": connectorType.MinimumLicenseRequired,
+			"supported_feature_ids":    connectorType.SupportedFeatureIDs,
+			"is_system_action_type":    connectorType.IsSystemActionType,
+		})
+	}
+
+	return tfList
+}
@@ -0,0 +1,31 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaConnectorTypes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaConnectorTypes,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kibana_connector_types.test", "connector_types.0.id"),
+					resource.TestCheckTypeSetElemAttr("data.kibana_connector_types.test", "connector_types.0.supported_feature_ids.*", "alerting"),
+				),
+			},
+		},
+	})
+}
+
+var testDataSourceKibanaConnectorTypes = `
+data "kibana_connector_types" "test" {
+  feature_id = "alerting"
+}
+`
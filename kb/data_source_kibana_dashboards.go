@@ -0,0 +1,185 @@
+// Find dashboards by title and tags
+// API documentation: https://www.elastic.co/guide/en/kibana/current/saved-objects-api-find.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"sort"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+func dataSourceKibanaDashboards() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_dashboards` can be used to find dashboards by title and tags, like the dashboards installed by integration packages.",
+		ReadContext: dataSourceKibanaDashboardsRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space where to find dashboards",
+			},
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The simple query string to search on dashboard title, like `[Logs Nginx]*`",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The tag names that dashboards must have",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of dashboards found",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"dashboards": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The dashboards found, sorted by title",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"kibana_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKibanaDashboardsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+	search := d.Get("search").(string)
+	tags := convertArrayInterfaceToArrayString(d.Get("tags").(*schema.Set).List())
+
+	client := m.(*kibanaMeta).client
+
+	parameters := &kbapi.OptionalFindParameters{
+		Fields: []string{"title"},
+	}
+	if search != "" {
+		parameters.Search = search
+		parameters.SearchFields = []string{"title"}
+	}
+	objects, err := findAllSavedObjects(client, "dashboard", space, parameters)
+	if err != nil {
+		return handleAPIError(err, "find dashboards")
+	}
+
+	tagObjects, err := findAllSavedObjects(client, "tag", space, &kbapi.OptionalFindParameters{
+		Fields: []string{"name"},
+	})
+	if err != nil {
+		return handleAPIError(err, "find tags")
+	}
+	tagNames := make(map[string]string, len(tagObjects))
+	for _, tagObject := range tagObjects {
+		attributes, _ := tagObject["attributes"].(map[string]any)
+		name, _ := attributes["name"].(string)
+		tagNames[tagObject["id"].(string)] = name
+	}
+
+	dashboards := flattenKibanaDashboards(objects, tagNames, tags)
+	ids := make([]string, 0, len(dashboards))
+	for _, dashboard := range dashboards {
+		ids = append(ids, dashboard["id"].(string))
+		dashboard["kibana_url"] = buildKibanaURL(client.Client.HostURL, space, basePathKibanaDashboardApp, dashboard["id"].(string))
+	}
+
+	d.SetId(space)
+	if err = d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("dashboards", dashboards); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Found %d dashboards successfully", len(dashboards))
+
+	return nil
+}
+
+// flattenKibanaDashboards return the dashboards that have all tags, sorted by title
+func flattenKibanaDashboards(objects []map[string]any, tagNames map[string]string, tags []string) []map[string]any {
+	dashboards := make([]map[string]any, 0, len(objects))
+
+	for _, object := range objects {
+		attributes, _ := object["attributes"].(map[string]any)
+		title, _ := attributes["title"].(string)
+
+		dashboardTags := make([]string, 0)
+		hasTags := map[string]bool{}
+		references, _ := object["references"].([]any)
+		for _, rawReference := range references {
+			reference, _ := rawReference.(map[string]any)
+			if reference["type"] != "tag" {
+				continue
+			}
+			if name, ok := tagNames[reference["id"].(string)]; ok {
+				dashboardTags = append(dashboardTags, name)
+				hasTags[name] = true
+			}
+		}
+		sort.Strings(dashboardTags)
+
+		hasAllTags := true
+		for _, tag := range tags {
+			if !hasTags[tag] {
+				hasAllTags = false
+				break
+			}
+		}
+		if !hasAllTags {
+			continue
+		}
+
+		dashboards = append(dashboards, map[string]any{
+			"id":    object["id"].(string),
+			"title": title,
+			"tags":  dashboardTags,
+		})
+	}
+
+	sort.SliceStable(dashboards, func(i, j int) bool {
+		if dashboards[i]["title"] == dashboards[j]["title"] {
+			return dashboards[i]["id"].(string) < dashboards[j]["id"].(string)
+		}
+		return dashboards[i]["title"].(string) < dashboards[j]["title"].(string)
+	})
+
+	return dashboards
+}
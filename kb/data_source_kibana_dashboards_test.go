@@ -0,0 +1,97 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaDashboards(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaDashboards,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kibana_dashboards.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.kibana_dashboards.test", "ids.0", "terraform-test"),
+					resource.TestCheckResourceAttr("data.kibana_dashboards.test", "dashboards.0.title", "[Terraform] Overview"),
+					resource.TestCheckResourceAttr("data.kibana_dashboards.test", "dashboards.0.tags.0", "terraform"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenKibanaDashboards(t *testing.T) {
+	objects := []map[string]any{
+		{
+			"id":         "2",
+			"attributes": map[string]any{"title": "[Nginx] Overview"},
+			"references": []any{
+				map[string]any{"type": "tag", "id": "tag-nginx", "name": "tag-ref-tag-nginx"},
+				map[string]any{"type": "tag", "id": "tag-managed", "name": "tag-ref-tag-managed"},
+			},
+		},
+		{
+			"id":         "1",
+			"attributes": map[string]any{"title": "[Nginx] Access"},
+			"references": []any{
+				map[string]any{"type": "tag", "id": "tag-nginx", "name": "tag-ref-tag-nginx"},
+				map[string]any{"type": "index-pattern", "id": "logs-*", "name": "kibanaSavedObjectMeta.searchSourceJSON.index"},
+			},
+		},
+		{
+			"id":         "3",
+			"attributes": map[string]any{"title": "Custom"},
+		},
+	}
+	tagNames := map[string]string{
+		"tag-nginx":   "Nginx",
+		"tag-managed": "Managed",
+	}
+
+	dashboards := flattenKibanaDashboards(objects, tagNames, nil)
+	if len(dashboards) != 3 || dashboards[0]["id"] != "3" || dashboards[1]["id"] != "1" || dashboards[2]["id"] != "2" {
+		t.Errorf("Expected all dashboards sorted by title, got %+v", dashboards)
+	}
+	if tags := dashboards[2]["tags"].([]string); len(tags) != 2 || tags[0] != "Managed" || tags[1] != "Nginx" {
+		t.Errorf("Expected tag names sorted, got %+v", tags)
+	}
+
+	dashboards = flattenKibanaDashboards(objects, tagNames, []string{"Nginx", "Managed"})
+	if len(dashboards) != 1 || dashboards[0]["id"] != "2" {
+		t.Errorf("Expected only dashboards with all tags, got %+v", dashboards)
+	}
+}
+
+var testDataSourceKibanaDashboards = `
+resource kibana_user_space "test" {
+  uid  = "terraform-test-dashboards"
+  name = "terraform-test-dashboards"
+}
+
+resource kibana_object "test" {
+  name  = "terraform-test-dashboards"
+  space = kibana_user_space.test.uid
+  data  = <<EOT
+{"attributes":{"name":"terraform","color":"#000000","description":""},"id":"terraform-test-tag","type":"tag"}
+{"attributes":{"title":"[Terraform] Overview","panelsJSON":"[]"},"id":"terraform-test","references":[{"id":"terraform-test-tag","name":"tag-ref-terraform-test-tag","type":"tag"}],"type":"dashboard"}
+{"attributes":{"title":"Other","panelsJSON":"[]"},"id":"terraform-test-other","type":"dashboard"}
+EOT
+  export_objects {
+    id   = "terraform-test"
+    type = "dashboard"
+  }
+}
+
+data "kibana_dashboards" "test" {
+  space      = kibana_user_space.test.uid
+  search     = "Terraform"
+  tags       = ["terraform"]
+  depends_on = [kibana_object.test]
+}
+`
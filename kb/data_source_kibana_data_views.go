@@ -0,0 +1,122 @@
+// Return the data views of space
+// API documentation: https://www.elastic.co/guide/en/kibana/current/data-views-api-get-all.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+func dataSourceKibanaDataViews() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_data_views` can be used to retrieve all data views of space, to create objects for each data view.",
+		ReadContext: dataSourceKibanaDataViewsRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space where to list data views",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of data views, sorted by title",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"data_views": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The data views, sorted by title",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"namespaces": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKibanaDataViewsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+
+	client := m.(*kibanaMeta).client
+
+	dataViews, err := listKibanaDataViews(client.Client, space)
+	if err != nil {
+		return handleAPIError(err, "list data views")
+	}
+	sortKibanaDataViews(dataViews)
+
+	ids := make([]string, 0, len(dataViews))
+	for _, dataView := range dataViews {
+		ids = append(ids, dataView.ID)
+	}
+
+	d.SetId(space)
+	if err = d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("data_views", flattenKibanaDataViews(dataViews)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Found %d data views successfully", len(dataViews))
+
+	return nil
+}
+
+// sortKibanaDataViews sort data views by title, then by ID to keep the same order when titles are the same
+func sortKibanaDataViews(dataViews []kibanaDataView) {
+	sort.SliceStable(dataViews, func(i, j int) bool {
+		if dataViews[i].Title == dataViews[j].Title {
+			return dataViews[i].ID < dataViews[j].ID
+		}
+		return dataViews[i].Title < dataViews[j].Title
+	})
+}
+
+func flattenKibanaDataViews(dataViews []kibanaDataView) []interface{} {
+	tfList := make([]interface{}, 0, len(dataViews))
+
+	for _, dataView := range dataViews {
+		tfList = append(tfList, map[string]interface{}{
+			"id":         dataView.ID,
+			"title":      dataView.Title,
+			"name":       dataView.Name,
+			"namespaces": dataView.Namespaces,
+		})
+	}
+
+	return tfList
+}
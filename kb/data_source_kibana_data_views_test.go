@@ -0,0 +1,65 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaDataViews(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaDataViews,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kibana_data_views.test", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.kibana_data_views.test", "ids.0", "terraform-test-logs"),
+					resource.TestCheckResourceAttr("data.kibana_data_views.test", "data_views.1.title", "metrics-*"),
+					resource.TestCheckResourceAttr("data.kibana_data_views.test", "data_views.1.namespaces.0", "terraform-test-data-views"),
+				),
+			},
+		},
+	})
+}
+
+func TestSortKibanaDataViews(t *testing.T) {
+	dataViews := []kibanaDataView{
+		{ID: "3", Title: "metrics-*"},
+		{ID: "2", Title: "logs-*"},
+		{ID: "1", Title: "logs-*"},
+	}
+
+	sortKibanaDataViews(dataViews)
+	if dataViews[0].ID != "1" || dataViews[1].ID != "2" || dataViews[2].ID != "3" {
+		t.Errorf("Expected data views sorted by title and ID, got %+v", dataViews)
+	}
+}
+
+var testDataSourceKibanaDataViews = `
+resource kibana_user_space "test" {
+  uid  = "terraform-test-data-views"
+  name = "terraform-test-data-views"
+}
+
+resource kibana_object "test" {
+  name  = "terraform-test-data-views"
+  space = kibana_user_space.test.uid
+  data  = <<EOT
+{"attributes":{"title":"logs-*","timeFieldName":"@timestamp"},"id":"terraform-test-logs","type":"index-pattern"}
+{"attributes":{"title":"metrics-*","timeFieldName":"@timestamp"},"id":"terraform-test-metrics","type":"index-pattern"}
+EOT
+  export_objects {
+    id   = "terraform-test-logs"
+    type = "index-pattern"
+  }
+}
+
+data "kibana_data_views" "test" {
+  space      = kibana_user_space.test.uid
+  depends_on = [kibana_object.test]
+}
+`
@@ -7,7 +7,6 @@ package kb
 import (
 	"context"
 
-	kibana "github.com/disaster37/go-kibana-rest/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -43,11 +42,14 @@ func dataSourceKibanaHostRead(ctx context.Context, d *schema.ResourceData, m int
 	var password string
 	var err error
 
-	conf := m.(*kibana.Client)
+	conf := m.(*kibanaMeta).client
 
 	url = conf.Client.HostURL
-	username = conf.Client.UserInfo.Username
-	password = conf.Client.UserInfo.Password
+	// There are no basic auth when API key is used
+	if conf.Client.UserInfo != nil {
+		username = conf.Client.UserInfo.Username
+		password = conf.Client.UserInfo.Password
+	}
 
 	d.SetId(url)
 	if err = d.Set("url", url); err != nil {
@@ -1,10 +1,13 @@
 package kb
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccDataSourceKibanaHost(t *testing.T) {
@@ -24,6 +27,35 @@ func TestAccDataSourceKibanaHost(t *testing.T) {
 	})
 }
 
+func TestDataSourceKibanaHostWithAPIKey(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("KIBANA_URL", "http://kibana.mock:5601")
+	t.Setenv("KIBANA_USERNAME", "")
+	t.Setenv("KIBANA_PASSWORD", "")
+	t.Setenv("KIBANA_API_KEY", "dGVzdDp0ZXN0")
+	t.Setenv("KIBANA_MOCK_ENDPOINTS_FILE", path+"/../fixtures/mock-endpoints.json")
+
+	provider := Provider()
+	if diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		t.Fatalf("err: %+v", diags)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceKibanaHost().Schema, map[string]interface{}{})
+	if diags := dataSourceKibanaHostRead(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("err: %+v", diags)
+	}
+	if d.Get("url").(string) != "http://kibana.mock:5601" {
+		t.Errorf("Unexpected url: %s", d.Get("url").(string))
+	}
+	if d.Get("username").(string) != "" || d.Get("password").(string) != "" {
+		t.Errorf("Expected no username and password with API key, got %s / %s", d.Get("username").(string), d.Get("password").(string))
+	}
+}
+
 func testCheckDataSourceKibanaHost(name string) resource.TestCheckFunc {
 	var url, username, password resource.TestCheckFunc
 
@@ -0,0 +1,95 @@
+// Return the license used by Kibana
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func dataSourceKibanaLicense() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_license` can be used to retrieve the license used by Kibana.",
+		ReadContext: dataSourceKibanaLicenseRead,
+
+		Schema: map[string]*schema.Schema{
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The license ID",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The license level (basic, gold, platinum, enterprise or trial)",
+			},
+			"mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The license mode",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The license status (active or expired)",
+			},
+			"expiry_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The license expiry date, as RFC3339 date. Empty if the license never expire",
+			},
+			"expiry_date_in_millis": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The license expiry date, as epoch in milliseconds",
+			},
+		},
+	}
+}
+
+func dataSourceKibanaLicenseRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+
+	client := m.(*kibanaMeta).client
+
+	license, err := getKibanaLicense(client.Client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if license == nil {
+		return diag.FromErr(errors.New("License not found, licensing plugin is maybe disabled"))
+	}
+
+	expiryDate := ""
+	if license.ExpiryDateInMillis > 0 {
+		expiryDate = time.UnixMilli(license.ExpiryDateInMillis).UTC().Format(time.RFC3339)
+	}
+
+	d.SetId(license.UID)
+	if err = d.Set("uid", license.UID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("type", license.Type); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("mode", license.Mode); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("status", license.Status); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("expiry_date", expiryDate); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("expiry_date_in_millis", license.ExpiryDateInMillis); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaLicense(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaLicense,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kibana_license.test", "uid"),
+					resource.TestCheckResourceAttrSet("data.kibana_license.test", "type"),
+					resource.TestCheckResourceAttr("data.kibana_license.test", "status", "active"),
+				),
+			},
+		},
+	})
+}
+
+var testDataSourceKibanaLicense = `
+data "kibana_license" "test" {
+}
+`
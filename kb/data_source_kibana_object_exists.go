@@ -0,0 +1,138 @@
+// Check saved object exists, to fail plan when object managed outside Terraform is missing
+// API documentation: https://www.elastic.co/guide/en/kibana/master/saved-objects-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+func dataSourceKibanaObjectExists() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_object_exists` can be used to check a saved object managed outside Terraform exists, by its ID or its title. It fail the plan when the object is missing.",
+		ReadContext: dataSourceKibanaObjectExistsRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space where the saved object is",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The saved object type",
+			},
+			"object_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"object_id", "title"},
+				Description:  "The saved object ID. It's the ID of object found when searched by title",
+			},
+			"title": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"object_id", "title"},
+				Description:  "The saved object title. It's the title of object found when searched by ID",
+			},
+			"error_message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The message added on error when the saved object is missing, to explain how to provide it",
+			},
+		},
+	}
+}
+
+func dataSourceKibanaObjectExistsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+	objectType := d.Get("type").(string)
+	objectID := d.Get("object_id").(string)
+	title := d.Get("title").(string)
+
+	client := m.(*kibanaMeta).client
+
+	if objectID != "" {
+		resolution, err := resolveKibanaSavedObject(client.Client, space, objectType, objectID)
+		if err != nil {
+			return handleAPIError(err, fmt.Sprintf("read %s %s", objectType, objectID))
+		}
+		if resolution == nil {
+			return kibanaObjectMissingDiagnostics(d, fmt.Sprintf("Saved object %s %s not found in space %s", objectType, objectID, space))
+		}
+		objectID = resolution.SavedObject.ID
+		title, _ = resolution.SavedObject.Attributes["title"].(string)
+	} else {
+		objects, err := findAllSavedObjects(client, objectType, space, &kbapi.OptionalFindParameters{
+			Fields:       []string{"title"},
+			Search:       fmt.Sprintf("%q", title),
+			SearchFields: []string{"title"},
+		})
+		if err != nil {
+			return handleAPIError(err, fmt.Sprintf("find %s %s", objectType, title))
+		}
+		ids := matchKibanaObjectsByTitle(objects, title)
+		switch len(ids) {
+		case 0:
+			return kibanaObjectMissingDiagnostics(d, fmt.Sprintf("Saved object %s with title %s not found in space %s", objectType, title, space))
+		case 1:
+			objectID = ids[0]
+		default:
+			return diag.Errorf("Many saved objects %s with title %s found in space %s: %s. Use object_id to choose one", objectType, title, space, strings.Join(ids, ", "))
+		}
+	}
+	log.Debugf("Found %s %s (%s)", objectType, objectID, title)
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", space, objectType, objectID))
+	if err = d.Set("object_id", objectID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("title", title); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Check %s %s exists successfully", objectType, objectID)
+
+	return nil
+}
+
+// kibanaObjectMissingDiagnostics return the error when saved object is missing, with the message given by user
+func kibanaObjectMissingDiagnostics(d *schema.ResourceData, summary string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   d.Get("error_message").(string),
+	}}
+}
+
+// matchKibanaObjectsByTitle return the sorted IDs of saved objects that have exactly the title.
+// The find API search on words, so it can return objects with other titles
+func matchKibanaObjectsByTitle(objects []map[string]any, title string) []string {
+	ids := make([]string, 0)
+	for _, object := range objects {
+		attributes, _ := object["attributes"].(map[string]any)
+		if objectTitle, _ := attributes["title"].(string); objectTitle != title {
+			continue
+		}
+		if id, ok := object["id"].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids
+}
@@ -0,0 +1,82 @@
+package kb
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaObjectExists(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaObjectExists,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kibana_object_exists.by_id", "title", "terraform-test-exists-*"),
+					resource.TestCheckResourceAttr("data.kibana_object_exists.by_title", "object_id", "terraform-test-exists"),
+				),
+			},
+			{
+				Config:      testDataSourceKibanaObjectExistsMissing,
+				ExpectError: regexp.MustCompile("not found"),
+			},
+		},
+	})
+}
+
+func TestMatchKibanaObjectsByTitle(t *testing.T) {
+	objects := []map[string]any{
+		{"id": "logs-b", "attributes": map[string]any{"title": "logs-*"}},
+		{"id": "logs-nginx", "attributes": map[string]any{"title": "logs-nginx-*"}},
+		{"id": "logs-a", "attributes": map[string]any{"title": "logs-*"}},
+		{"id": "no-title", "attributes": map[string]any{}},
+	}
+
+	ids := matchKibanaObjectsByTitle(objects, "logs-*")
+	if len(ids) != 2 || ids[0] != "logs-a" || ids[1] != "logs-b" {
+		t.Errorf("Unexpected IDs: %s", ids)
+	}
+	if ids = matchKibanaObjectsByTitle(objects, "metrics-*"); len(ids) != 0 {
+		t.Errorf("Expected no IDs, got %s", ids)
+	}
+}
+
+var testDataSourceKibanaObjectExists = `
+resource kibana_object "test" {
+  name  = "terraform-test-exists"
+  data  = <<EOT
+{"attributes":{"title":"terraform-test-exists-*","timeFieldName":"@timestamp"},"id":"terraform-test-exists","type":"index-pattern"}
+EOT
+  export_objects {
+    id   = "terraform-test-exists"
+    type = "index-pattern"
+  }
+}
+
+data "kibana_object_exists" "by_id" {
+  type      = "index-pattern"
+  object_id = "terraform-test-exists"
+
+  depends_on = [kibana_object.test]
+}
+
+data "kibana_object_exists" "by_title" {
+  type  = "index-pattern"
+  title = "terraform-test-exists-*"
+
+  depends_on = [kibana_object.test]
+}
+`
+
+var testDataSourceKibanaObjectExistsMissing = `
+data "kibana_object_exists" "missing" {
+  type          = "index-pattern"
+  title         = "terraform-test-missing-*"
+  error_message = "Install the integration package first"
+}
+`
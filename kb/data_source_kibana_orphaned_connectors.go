@@ -0,0 +1,141 @@
+// Return the connectors not referenced by any rule
+// API documentation: https://www.elastic.co/guide/en/kibana/current/get-all-connectors-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+func dataSourceKibanaOrphanedConnectors() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_orphaned_connectors` can be used to retrieve the connectors not referenced by any rule, to clean them up.",
+		ReadContext: dataSourceKibanaOrphanedConnectorsRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space where to list connectors",
+			},
+			"include_preconfigured": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Include the preconfigured connectors. They can't be deleted from API",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of orphaned connectors, sorted",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"connectors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The orphaned connectors, sorted by ID",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connector_type_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_preconfigured": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_deprecated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKibanaOrphanedConnectorsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+	includePreconfigured := d.Get("include_preconfigured").(bool)
+
+	client := m.(*kibanaMeta).client
+
+	connectors, err := listKibanaConnectors(client.Client, space)
+	if err != nil {
+		return handleAPIError(err, "list connectors")
+	}
+	orphanedConnectors := filterKibanaOrphanedConnectors(connectors, includePreconfigured)
+
+	ids := make([]string, 0, len(orphanedConnectors))
+	for _, connector := range orphanedConnectors {
+		ids = append(ids, connector.ID)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%t", space, includePreconfigured))
+	if err = d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("connectors", flattenKibanaConnectors(orphanedConnectors)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Found %d orphaned connectors on %d connectors", len(orphanedConnectors), len(connectors))
+
+	return nil
+}
+
+// filterKibanaOrphanedConnectors keep only connectors not referenced by any rule, sorted by ID
+func filterKibanaOrphanedConnectors(connectors []kibanaConnector, includePreconfigured bool) []kibanaConnector {
+	orphanedConnectors := make([]kibanaConnector, 0)
+	for _, connector := range connectors {
+		if connector.ReferencedByCount != 0 {
+			continue
+		}
+		if connector.IsPreconfigured && !includePreconfigured {
+			continue
+		}
+		orphanedConnectors = append(orphanedConnectors, connector)
+	}
+	sort.Slice(orphanedConnectors, func(i, j int) bool {
+		return orphanedConnectors[i].ID < orphanedConnectors[j].ID
+	})
+
+	return orphanedConnectors
+}
+
+func flattenKibanaConnectors(connectors []kibanaConnector) []interface{} {
+	tfList := make([]interface{}, 0, len(connectors))
+
+	for _, connector := range connectors {
+		tfList = append(tfList, map[string]interface{}{
+			"id":                connector.ID,
+			"name":              connector.Name,
+			"connector_type_id": connector.ConnectorTypeID,
+			"is_preconfigured":  connector.IsPreconfigured,
+			"is_deprecated":     connector.IsDeprecated,
+		})
+	}
+
+	return tfList
+}
@@ -0,0 +1,47 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaOrphanedConnectors(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaOrphanedConnectors,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kibana_orphaned_connectors.test", "ids.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterKibanaOrphanedConnectors(t *testing.T) {
+	connectors := []kibanaConnector{
+		{ID: "slack", ReferencedByCount: 0},
+		{ID: "email", ReferencedByCount: 3},
+		{ID: "preconfigured-index", IsPreconfigured: true, ReferencedByCount: 0},
+		{ID: "pagerduty", ReferencedByCount: 0},
+	}
+
+	orphanedConnectors := filterKibanaOrphanedConnectors(connectors, false)
+	if len(orphanedConnectors) != 2 || orphanedConnectors[0].ID != "pagerduty" || orphanedConnectors[1].ID != "slack" {
+		t.Errorf("Expected pagerduty and slack, got %+v", orphanedConnectors)
+	}
+
+	orphanedConnectors = filterKibanaOrphanedConnectors(connectors, true)
+	if len(orphanedConnectors) != 3 || orphanedConnectors[1].ID != "preconfigured-index" {
+		t.Errorf("Expected pagerduty, preconfigured-index and slack, got %+v", orphanedConnectors)
+	}
+}
+
+var testDataSourceKibanaOrphanedConnectors = `
+data "kibana_orphaned_connectors" "test" {}
+`
@@ -0,0 +1,140 @@
+// Render the kibana.yml snippet of preconfigured connector
+// API documentation: https://www.elastic.co/guide/en/kibana/current/pre-configured-connectors.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceKibanaPreconfiguredConnector() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_preconfigured_connector` can be used to render the kibana.yml snippet of preconfigured connector, so secrets never go through Terraform state.",
+		ReadContext: dataSourceKibanaPreconfiguredConnectorRead,
+
+		Schema: map[string]*schema.Schema{
+			"connector_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The connector ID, used by rules to reference it",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The connector name",
+			},
+			"connector_type_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\.[a-z0-9-]+$`), "must be a connector type like .slack"),
+				Description:  "The connector type, like .slack, .email or .webhook",
+			},
+			"config": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The connector configuration",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"secrets": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The connector secrets. Values must reference environment variables or keystore settings, like ${SLACK_WEBHOOK_URL}",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"kibana_yml": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The kibana.yml snippet that declare the connector",
+			},
+			"kibana_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of connector page on Kibana UI, once declared on kibana.yml",
+			},
+		},
+	}
+}
+
+func dataSourceKibanaPreconfiguredConnectorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	connectorID := d.Get("connector_id").(string)
+	secrets := convertMapInterfaceToMapString(d.Get("secrets").(map[string]interface{}))
+
+	// Secrets in clear text must not be written on kibana.yml and on state
+	for key, value := range secrets {
+		if !isKibanaSecretReference(value) {
+			return diag.Errorf("Secret %s of connector %s must reference environment variable or keystore setting, like ${MY_SECRET}", key, connectorID)
+		}
+	}
+
+	kibanaYML := renderKibanaPreconfiguredConnector(
+		connectorID,
+		d.Get("name").(string),
+		d.Get("connector_type_id").(string),
+		convertMapInterfaceToMapString(d.Get("config").(map[string]interface{})),
+		secrets,
+	)
+
+	d.SetId(connectorID)
+	if err = d.Set("kibana_yml", kibanaYML); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("kibana_url", buildKibanaURL(m.(*kibanaMeta).client.Client.HostURL, "default", basePathKibanaConnectorApp, connectorID)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// isKibanaSecretReference return true if value is only a reference to environment variable or keystore setting
+func isKibanaSecretReference(value string) bool {
+	return strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") && strings.Count(value, "${") == 1
+}
+
+// renderKibanaPreconfiguredConnector permit to render the kibana.yml snippet of preconfigured connector.
+// Keys are sorted so the output is stable between plans
+func renderKibanaPreconfiguredConnector(connectorID string, name string, connectorTypeID string, config map[string]string, secrets map[string]string) string {
+	var sb strings.Builder
+
+	sb.WriteString("xpack.actions.preconfigured:\n")
+	sb.WriteString(fmt.Sprintf("  %s:\n", strconv.Quote(connectorID)))
+	sb.WriteString(fmt.Sprintf("    name: %s\n", strconv.Quote(name)))
+	sb.WriteString(fmt.Sprintf("    actionTypeId: %s\n", strconv.Quote(connectorTypeID)))
+
+	for _, section := range []struct {
+		name   string
+		values map[string]string
+	}{{"config", config}, {"secrets", secrets}} {
+		if len(section.values) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(section.values))
+		for key := range section.values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		sb.WriteString(fmt.Sprintf("    %s:\n", section.name))
+		for _, key := range keys {
+			sb.WriteString(fmt.Sprintf("      %s: %s\n", key, strconv.Quote(section.values[key])))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,78 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaPreconfiguredConnector(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaPreconfiguredConnector,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kibana_preconfigured_connector.test", "kibana_yml", `xpack.actions.preconfigured:
+  "terraform-test":
+    name: "Terraform test"
+    actionTypeId: ".slack"
+    secrets:
+      webhookUrl: "${SLACK_WEBHOOK_URL}"
+`),
+				),
+			},
+		},
+	})
+}
+
+func TestRenderKibanaPreconfiguredConnector(t *testing.T) {
+	expected := `xpack.actions.preconfigured:
+  "my-webhook":
+    name: "My webhook"
+    actionTypeId: ".webhook"
+    config:
+      method: "post"
+      url: "https://example.com"
+    secrets:
+      password: "${WEBHOOK_PASSWORD}"
+      user: "${WEBHOOK_USER}"
+`
+	result := renderKibanaPreconfiguredConnector(
+		"my-webhook",
+		"My webhook",
+		".webhook",
+		map[string]string{"url": "https://example.com", "method": "post"},
+		map[string]string{"user": "${WEBHOOK_USER}", "password": "${WEBHOOK_PASSWORD}"},
+	)
+	if result != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, result)
+	}
+}
+
+func TestIsKibanaSecretReference(t *testing.T) {
+	for value, expected := range map[string]bool{
+		"${SLACK_WEBHOOK_URL}":            true,
+		"https://hooks.slack.com/xxx":     false,
+		"prefix-${SLACK_WEBHOOK_URL}":     false,
+		"${SLACK_WEBHOOK_URL}${PASSWORD}": false,
+	} {
+		if isKibanaSecretReference(value) != expected {
+			t.Errorf("%s: expected %t", value, expected)
+		}
+	}
+}
+
+var testDataSourceKibanaPreconfiguredConnector = `
+data "kibana_preconfigured_connector" "test" {
+  connector_id      = "terraform-test"
+  name              = "Terraform test"
+  connector_type_id = ".slack"
+  secrets = {
+    webhookUrl = "$${SLACK_WEBHOOK_URL}"
+  }
+}
+`
@@ -0,0 +1,85 @@
+// Return the informations fetched by the provider when it connect on Kibana
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKibanaProviderInfo() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_provider_info` can be used to retrieve the Kibana version, the authenticated user, the license and the deployment flavor used by the provider.",
+		ReadContext: dataSourceKibanaProviderInfoRead,
+
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Kibana version",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The authenticated user",
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The roles of the authenticated user",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"license_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The license level (basic, gold, platinum, enterprise or trial)",
+			},
+			"license_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The license status (active or expired)",
+			},
+			"serverless": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True when Kibana is a serverless project",
+			},
+		},
+	}
+}
+
+func dataSourceKibanaProviderInfoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+
+	conf := m.(*kibanaMeta)
+
+	d.SetId(conf.client.Client.HostURL)
+	if err = d.Set("version", conf.version); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("username", conf.username); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("roles", conf.roles); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("serverless", conf.serverless); err != nil {
+		return diag.FromErr(err)
+	}
+	if conf.license != nil {
+		if err = d.Set("license_type", conf.license.Type); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set("license_status", conf.license.Status); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package kb
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaProviderInfo(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaProviderInfo,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kibana_provider_info.test", "version"),
+					resource.TestCheckResourceAttr("data.kibana_provider_info.test", "username", os.Getenv("KIBANA_USERNAME")),
+					resource.TestCheckResourceAttr("data.kibana_provider_info.test", "license_status", "active"),
+				),
+			},
+		},
+	})
+}
+
+var testDataSourceKibanaProviderInfo = `
+data "kibana_provider_info" "test" {
+}
+`
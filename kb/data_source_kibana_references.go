@@ -0,0 +1,211 @@
+// Resolve names of connectors, data views and dashboards to their IDs
+// API documentation:
+//   - https://www.elastic.co/guide/en/kibana/current/get-all-connectors-api.html
+//   - https://www.elastic.co/guide/en/kibana/current/saved-objects-api-find.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// kibanaReferenceCandidate is one object that can match a name
+type kibanaReferenceCandidate struct {
+	ID    string
+	Names []string
+}
+
+func dataSourceKibanaReferences() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_references` can be used to resolve a batch of connector, data view and dashboard names to their IDs, with one list call by object type.",
+		ReadContext: dataSourceKibanaReferencesRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space where to resolve names",
+			},
+			"connectors": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The connector names to resolve",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"data_views": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The data view names or index patterns to resolve",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"dashboards": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The dashboard titles to resolve",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"connector_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The connector IDs, by name",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"data_view_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The data view IDs, by name or index pattern",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"dashboard_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The dashboard IDs, by title",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKibanaReferencesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+	connectorNames := convertArrayInterfaceToArrayString(d.Get("connectors").(*schema.Set).List())
+	dataViewNames := convertArrayInterfaceToArrayString(d.Get("data_views").(*schema.Set).List())
+	dashboardTitles := convertArrayInterfaceToArrayString(d.Get("dashboards").(*schema.Set).List())
+
+	client := m.(*kibanaMeta).client
+
+	connectorIDs := map[string]string{}
+	if len(connectorNames) > 0 {
+		connectors, err := listKibanaConnectors(client.Client, space)
+		if err != nil {
+			return handleAPIError(err, "list connectors")
+		}
+		if connectorIDs, err = matchKibanaReferences("connector", connectorNames, newKibanaConnectorCandidates(connectors)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	dataViewIDs := map[string]string{}
+	if len(dataViewNames) > 0 {
+		candidates, err := findKibanaReferenceCandidates(m.(*kibanaMeta), "index-pattern", space, "name", "title")
+		if err != nil {
+			return handleAPIError(err, "find data views")
+		}
+		if dataViewIDs, err = matchKibanaReferences("data view", dataViewNames, candidates); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	dashboardIDs := map[string]string{}
+	if len(dashboardTitles) > 0 {
+		candidates, err := findKibanaReferenceCandidates(m.(*kibanaMeta), "dashboard", space, "title")
+		if err != nil {
+			return handleAPIError(err, "find dashboards")
+		}
+		if dashboardIDs, err = matchKibanaReferences("dashboard", dashboardTitles, candidates); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(space)
+	if err = d.Set("connector_ids", connectorIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("data_view_ids", dataViewIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("dashboard_ids", dashboardIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Resolve %d references successfully", len(connectorIDs)+len(dataViewIDs)+len(dashboardIDs))
+
+	return nil
+}
+
+// findKibanaReferenceCandidates permit to get all saved objects of type, with the attributes that can match a name
+func findKibanaReferenceCandidates(meta *kibanaMeta, objectType string, space string, fields ...string) ([]kibanaReferenceCandidate, error) {
+	objects, err := findAllSavedObjects(meta.client, objectType, space, &kbapi.OptionalFindParameters{
+		Fields: fields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]kibanaReferenceCandidate, 0, len(objects))
+	for _, object := range objects {
+		attributes, _ := object["attributes"].(map[string]any)
+		candidate := kibanaReferenceCandidate{
+			ID:    object["id"].(string),
+			Names: make([]string, 0, len(fields)),
+		}
+		for _, field := range fields {
+			if name, ok := attributes[field].(string); ok && name != "" {
+				candidate.Names = append(candidate.Names, name)
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// matchKibanaReferences return the ID of each name.
+// It return error listing all names not found or that match many objects, to fix them in one time
+func matchKibanaReferences(kind string, names []string, candidates []kibanaReferenceCandidate) (map[string]string, error) {
+	ids := make(map[string]string, len(names))
+	errs := make([]string, 0)
+
+	for _, name := range names {
+		matches := make([]string, 0, 1)
+		for _, candidate := range candidates {
+			for _, candidateName := range candidate.Names {
+				if candidateName == name {
+					matches = append(matches, candidate.ID)
+					break
+				}
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			errs = append(errs, fmt.Sprintf("No %s named %s", kind, name))
+		case 1:
+			ids[name] = matches[0]
+		default:
+			sort.Strings(matches)
+			errs = append(errs, fmt.Sprintf("Many %ss named %s: %s", kind, name, strings.Join(matches, ", ")))
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return nil, fmt.Errorf("Failed to resolve %s references:\n%s", kind, strings.Join(errs, "\n"))
+	}
+
+	return ids, nil
+}
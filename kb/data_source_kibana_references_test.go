@@ -0,0 +1,77 @@
+package kb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaReferences(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaReferences,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kibana_references.test", "data_view_ids.%", "1"),
+					resource.TestCheckResourceAttr("data.kibana_references.test", "data_view_ids.terraform-test", "terraform-test"),
+				),
+			},
+		},
+	})
+}
+
+func TestMatchKibanaReferences(t *testing.T) {
+	candidates := []kibanaReferenceCandidate{
+		{ID: "1", Names: []string{"Logs", "logs-*"}},
+		{ID: "2", Names: []string{"metrics-*"}},
+		{ID: "3", Names: []string{"Duplicate"}},
+		{ID: "4", Names: []string{"Duplicate"}},
+	}
+
+	ids, err := matchKibanaReferences("data view", []string{"Logs", "metrics-*"}, candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids["Logs"] != "1" || ids["metrics-*"] != "2" {
+		t.Errorf("Expected Logs to 1 and metrics-* to 2, got %+v", ids)
+	}
+
+	_, err = matchKibanaReferences("data view", []string{"Duplicate", "Missing", "logs-*"}, candidates)
+	if err == nil {
+		t.Fatal("Expected error on missing and duplicated names")
+	}
+	if !strings.Contains(err.Error(), "No data view named Missing") || !strings.Contains(err.Error(), "Many data views named Duplicate: 3, 4") {
+		t.Errorf("Expected all resolution errors, got %s", err.Error())
+	}
+}
+
+var testDataSourceKibanaReferences = `
+resource kibana_user_space "test" {
+  uid  = "terraform-test-references"
+  name = "terraform-test-references"
+}
+
+resource kibana_object "test" {
+  name  = "terraform-test-references"
+  space = kibana_user_space.test.uid
+  data  = <<EOT
+{"attributes":{"title":"terraform-test","timeFieldName":"@timestamp"},"id":"terraform-test","type":"index-pattern"}
+EOT
+  export_objects {
+    id   = "terraform-test"
+    type = "index-pattern"
+  }
+}
+
+data "kibana_references" "test" {
+  space      = kibana_user_space.test.uid
+  data_views = ["terraform-test"]
+
+  depends_on = [kibana_object.test]
+}
+`
@@ -0,0 +1,215 @@
+// Compose the Kibana privileges of role
+// API documentation: https://www.elastic.co/guide/en/kibana/current/role-management-api-put.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+// kibanaRoleTemplate is the Kibana privileges of role for some spaces, as expected by role API
+type kibanaRoleTemplate struct {
+	Base    []string            `json:"base"`
+	Feature map[string][]string `json:"feature"`
+	Spaces  []string            `json:"spaces"`
+}
+
+func dataSourceKibanaRoleTemplates() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_role_templates` can be used to compose the Kibana privileges of role, with base or feature privileges by spaces, and to check them before creating role.",
+		ReadContext: dataSourceKibanaRoleTemplatesRead,
+
+		Schema: map[string]*schema.Schema{
+			"kibana": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The Kibana privileges, one by group of spaces",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"spaces": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "The spaces where privileges are granted. Use `*` for all spaces",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"base": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The base privileges, that grant all features. Conflict with `feature`",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"all", "read"}, false),
+							},
+						},
+						"feature": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The feature privileges. Conflict with `base`",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The feature ID, like `discover`",
+									},
+									"privileges": {
+										Type:        schema.TypeSet,
+										Required:    true,
+										Description: "The feature privileges, like `all`, `read`, `minimal_read` or a sub feature privilege",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"validate_features": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Check the features and privileges against the features registered on Kibana",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Kibana privileges as JSON, like `kibana` on role API",
+			},
+		},
+	}
+}
+
+func dataSourceKibanaRoleTemplatesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	validateFeatures := d.Get("validate_features").(bool)
+
+	templates := buildKibanaRoleTemplates(d.Get("kibana").([]interface{}))
+
+	var features []kibanaFeature
+	if validateFeatures {
+		client := m.(*kibanaMeta).client
+		features, err = listKibanaFeatures(client.Client)
+		if err != nil {
+			return handleAPIError(err, "list features")
+		}
+	}
+	if err = validateKibanaRoleTemplates(templates, features); err != nil {
+		return diag.FromErr(err)
+	}
+
+	templatesJSON, err := json.Marshal(templates)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(templatesJSON)))
+	if err = d.Set("json", string(templatesJSON)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Compose %d role templates successfully", len(templates))
+
+	return nil
+}
+
+// buildKibanaRoleTemplates permit to build role templates, with sorted spaces and privileges to get stable JSON
+func buildKibanaRoleTemplates(raws []interface{}) []kibanaRoleTemplate {
+	templates := make([]kibanaRoleTemplate, 0, len(raws))
+
+	for _, raw := range raws {
+		m := raw.(map[string]interface{})
+		template := kibanaRoleTemplate{
+			Base:    convertArrayInterfaceToArrayString(m["base"].(*schema.Set).List()),
+			Feature: map[string][]string{},
+			Spaces:  convertArrayInterfaceToArrayString(m["spaces"].(*schema.Set).List()),
+		}
+		for _, rawFeature := range m["feature"].([]interface{}) {
+			feature := rawFeature.(map[string]interface{})
+			privileges := convertArrayInterfaceToArrayString(feature["privileges"].(*schema.Set).List())
+			sort.Strings(privileges)
+			template.Feature[feature["name"].(string)] = privileges
+		}
+		sort.Strings(template.Base)
+		sort.Strings(template.Spaces)
+
+		templates = append(templates, template)
+	}
+
+	return templates
+}
+
+// validateKibanaRoleTemplates permit to check role templates as Kibana does when creating role.
+// The features and privileges are checked only when features is not nil
+func validateKibanaRoleTemplates(templates []kibanaRoleTemplate, features []kibanaFeature) error {
+	var featurePrivileges map[string]map[string]bool
+	if features != nil {
+		featurePrivileges = make(map[string]map[string]bool, len(features))
+		for _, feature := range features {
+			featurePrivileges[feature.ID] = feature.privilegeNames()
+		}
+	}
+
+	spaces := map[string]int{}
+	for i, template := range templates {
+		if len(template.Base) > 0 && len(template.Feature) > 0 {
+			return fmt.Errorf("kibana.%d: base and feature privileges can't be set together", i)
+		}
+		if len(template.Base) == 0 && len(template.Feature) == 0 {
+			return fmt.Errorf("kibana.%d: base or feature privileges must be set", i)
+		}
+		for _, space := range template.Spaces {
+			if space == "*" && len(template.Spaces) > 1 {
+				return fmt.Errorf("kibana.%d: space * grant all spaces, it can't be set with other spaces", i)
+			}
+			if j, ok := spaces[space]; ok {
+				return fmt.Errorf("kibana.%d: space %s already has privileges on kibana.%d", i, space, j)
+			}
+			spaces[space] = i
+		}
+
+		if featurePrivileges == nil {
+			continue
+		}
+		for name, privileges := range template.Feature {
+			allowedPrivileges, ok := featurePrivileges[name]
+			if !ok {
+				return fmt.Errorf("kibana.%d: feature %s not exist on Kibana", i, name)
+			}
+			for _, privilege := range privileges {
+				if !allowedPrivileges[privilege] {
+					return fmt.Errorf("kibana.%d: privilege %s not exist on feature %s, expected one of %s", i, privilege, name, strings.Join(sortedKeys(allowedPrivileges), ", "))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys return the keys of map, sorted
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,132 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaRoleTemplates(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaRoleTemplates,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kibana_role_templates.test", "json", `[{"base":[],"feature":{"dashboard":["read"],"discover":["minimal_read","url_create"]},"spaces":["default","team-a"]},{"base":["read"],"feature":{},"spaces":["team-b"]}]`),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateKibanaRoleTemplates(t *testing.T) {
+	features := []kibanaFeature{
+		{
+			ID:         "discover",
+			Privileges: map[string]any{"all": map[string]any{}, "read": map[string]any{}},
+			SubFeatures: []kibanaFeatureSubFeature{{
+				Name: "Short URLs",
+				PrivilegeGroups: []kibanaFeatureSubFeaturePrivilegeGroup{{
+					GroupType:  "independent",
+					Privileges: []kibanaFeatureSubFeaturePrivilege{{ID: "url_create"}},
+				}},
+			}},
+		},
+		{
+			ID:         "dashboard",
+			Privileges: map[string]any{"all": map[string]any{}, "read": map[string]any{}},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		templates []kibanaRoleTemplate
+		features  []kibanaFeature
+		isError   bool
+	}{
+		{
+			name: "valid",
+			templates: []kibanaRoleTemplate{
+				{Feature: map[string][]string{"discover": {"minimal_read", "url_create"}, "dashboard": {"read"}}, Spaces: []string{"default"}},
+				{Base: []string{"all"}, Spaces: []string{"team-a"}},
+			},
+			features: features,
+		},
+		{
+			name:      "base with feature",
+			templates: []kibanaRoleTemplate{{Base: []string{"read"}, Feature: map[string][]string{"dashboard": {"read"}}, Spaces: []string{"default"}}},
+			isError:   true,
+		},
+		{
+			name:      "no privilege",
+			templates: []kibanaRoleTemplate{{Spaces: []string{"default"}}},
+			isError:   true,
+		},
+		{
+			name:      "all spaces with other space",
+			templates: []kibanaRoleTemplate{{Base: []string{"read"}, Spaces: []string{"*", "default"}}},
+			isError:   true,
+		},
+		{
+			name: "space set twice",
+			templates: []kibanaRoleTemplate{
+				{Base: []string{"read"}, Spaces: []string{"default"}},
+				{Base: []string{"all"}, Spaces: []string{"default"}},
+			},
+			isError: true,
+		},
+		{
+			name:      "unknown feature",
+			templates: []kibanaRoleTemplate{{Feature: map[string][]string{"discovery": {"read"}}, Spaces: []string{"default"}}},
+			features:  features,
+			isError:   true,
+		},
+		{
+			name:      "unknown feature without validation",
+			templates: []kibanaRoleTemplate{{Feature: map[string][]string{"discovery": {"read"}}, Spaces: []string{"default"}}},
+		},
+		{
+			name:      "minimal privilege without sub feature",
+			templates: []kibanaRoleTemplate{{Feature: map[string][]string{"dashboard": {"minimal_read"}}, Spaces: []string{"default"}}},
+			features:  features,
+			isError:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := validateKibanaRoleTemplates(testCase.templates, testCase.features)
+		if testCase.isError && err == nil {
+			t.Errorf("%s: expected error", testCase.name)
+		}
+		if !testCase.isError && err != nil {
+			t.Errorf("%s: unexpected error: %s", testCase.name, err.Error())
+		}
+	}
+}
+
+var testDataSourceKibanaRoleTemplates = `
+data "kibana_role_templates" "test" {
+  kibana {
+    spaces = ["default", "team-a"]
+
+    feature {
+      name       = "discover"
+      privileges = ["minimal_read", "url_create"]
+    }
+
+    feature {
+      name       = "dashboard"
+      privileges = ["read"]
+    }
+  }
+
+  kibana {
+    spaces = ["team-b"]
+    base   = ["read"]
+  }
+}
+`
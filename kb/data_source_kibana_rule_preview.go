@@ -0,0 +1,127 @@
+// Preview detection rule on past timeframe
+// API documentation: not documented, API used by Security UI
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+func dataSourceKibanaRulePreview() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_rule_preview` can be used to run a detection rule on past timeframe, without writing alerts, to tune it on CI before enabling it.",
+		ReadContext: dataSourceKibanaRulePreviewRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space where to run the preview",
+			},
+			"rule": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The detection rule to preview, as JSON like the create rule API body",
+			},
+			"timeframe_end": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The end of timeframe, as RFC3339 date. Default to now",
+			},
+			"invocation_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 200),
+				Description:  "The number of rule executions to simulate, going back from timeframe end by rule interval",
+			},
+			"preview_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The preview ID. Would-be alerts are written on preview alert index with this rule UUID",
+			},
+			"is_aborted": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if the preview was aborted because it took too long",
+			},
+			"errors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The errors of all executions",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The warnings of all executions",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKibanaRulePreviewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+	timeframeEnd := d.Get("timeframe_end").(string)
+	invocationCount := d.Get("invocation_count").(int)
+
+	rule := map[string]any{}
+	if err = json.Unmarshal([]byte(d.Get("rule").(string)), &rule); err != nil {
+		return diag.FromErr(err)
+	}
+	if timeframeEnd == "" {
+		timeframeEnd = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	client := m.(*kibanaMeta).client
+
+	preview, err := previewKibanaDetectionRule(client.Client, space, rule, timeframeEnd, invocationCount)
+	if err != nil {
+		return handleAPIError(err, "preview rule")
+	}
+	log.Debugf("Preview rule successfully: %+v", preview)
+
+	errs := make([]string, 0)
+	warnings := make([]string, 0)
+	for _, previewLog := range preview.Logs {
+		errs = append(errs, previewLog.Errors...)
+		warnings = append(warnings, previewLog.Warnings...)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", space, preview.PreviewID))
+	if err = d.Set("preview_id", preview.PreviewID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("is_aborted", preview.IsAborted); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("errors", errs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("warnings", warnings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Preview rule %s with %d errors and %d warnings", preview.PreviewID, len(errs), len(warnings))
+
+	return nil
+}
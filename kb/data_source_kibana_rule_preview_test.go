@@ -0,0 +1,43 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaRulePreview(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaRulePreview,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kibana_rule_preview.test", "preview_id"),
+					resource.TestCheckResourceAttr("data.kibana_rule_preview.test", "errors.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+var testDataSourceKibanaRulePreview = `
+data "kibana_rule_preview" "test" {
+  invocation_count = 1
+  rule = jsonencode({
+    name        = "Terraform test"
+    description = "Rule previewed by Terraform"
+    type        = "query"
+    language    = "kuery"
+    query       = "event.action:*"
+    index       = ["logs-*"]
+    risk_score  = 21
+    severity    = "low"
+    interval    = "5m"
+    from        = "now-6m"
+  })
+}
+`
@@ -0,0 +1,99 @@
+// Resolve saved object, following the aliases created by space migrations
+// API documentation: https://www.elastic.co/guide/en/kibana/master/saved-objects-api-resolve.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+func dataSourceKibanaSavedObjectResolve() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_saved_object_resolve` can be used to resolve saved object by its ID, following the aliases created when IDs are remapped during upgrades.",
+		ReadContext: dataSourceKibanaSavedObjectResolveRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space where the saved object is",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The saved object type",
+			},
+			"object_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The saved object ID to resolve, current or legacy",
+			},
+			"outcome": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The resolution outcome: exactMatch, aliasMatch or conflict",
+			},
+			"resolved_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The final ID of saved object",
+			},
+			"alias_target_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID targeted by alias, when outcome is aliasMatch or conflict",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The saved object title, if any",
+			},
+		},
+	}
+}
+
+func dataSourceKibanaSavedObjectResolveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+	objectType := d.Get("type").(string)
+	objectID := d.Get("object_id").(string)
+
+	client := m.(*kibanaMeta).client
+
+	resolution, err := resolveKibanaSavedObject(client.Client, space, objectType, objectID)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("resolve %s %s", objectType, objectID))
+	}
+	if resolution == nil {
+		return diag.Errorf("Saved object %s %s not found in space %s", objectType, objectID, space)
+	}
+	log.Debugf("Resolve %s %s successfully: %+v", objectType, objectID, resolution)
+
+	title, _ := resolution.SavedObject.Attributes["title"].(string)
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", space, objectType, objectID))
+	if err = d.Set("outcome", resolution.Outcome); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("resolved_id", resolution.SavedObject.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("alias_target_id", resolution.AliasTargetID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("title", title); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Resolve %s %s to %s (%s) successfully", objectType, objectID, resolution.SavedObject.ID, resolution.Outcome)
+
+	return nil
+}
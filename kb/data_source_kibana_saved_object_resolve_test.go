@@ -0,0 +1,52 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaSavedObjectResolve(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaSavedObjectResolve,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kibana_saved_object_resolve.test", "outcome", "exactMatch"),
+					resource.TestCheckResourceAttr("data.kibana_saved_object_resolve.test", "resolved_id", "terraform-test"),
+				),
+			},
+		},
+	})
+}
+
+var testDataSourceKibanaSavedObjectResolve = `
+resource kibana_user_space "test" {
+  uid  = "terraform-test-resolve"
+  name = "terraform-test-resolve"
+}
+
+resource kibana_object "test" {
+  name  = "terraform-test-resolve"
+  space = kibana_user_space.test.uid
+  data  = <<EOT
+{"attributes":{"title":"terraform-test","timeFieldName":"@timestamp"},"id":"terraform-test","type":"index-pattern"}
+EOT
+  export_objects {
+    id   = "terraform-test"
+    type = "index-pattern"
+  }
+}
+
+data "kibana_saved_object_resolve" "test" {
+  space     = kibana_user_space.test.uid
+  type      = "index-pattern"
+  object_id = "terraform-test"
+
+  depends_on = [kibana_object.test]
+}
+`
@@ -0,0 +1,156 @@
+// Export the configuration of space as JSON snapshot
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// volatileSavedObjectFields are the fields that change without user modification
+var volatileSavedObjectFields = []string{
+	"version",
+	"updated_at",
+	"created_at",
+	"coreMigrationVersion",
+	"migrationVersion",
+	"typeMigrationVersion",
+}
+
+func dataSourceKibanaSpaceExport() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_space_export` can be used to export the advanced settings and saved objects of space as JSON snapshot, to audit or diff environments.",
+		ReadContext: dataSourceKibanaSpaceExportRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space to export",
+			},
+			"include_settings": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Export the advanced settings changed on space",
+			},
+			"export_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The saved object types to export",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"settings_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The advanced settings changed on space, as JSON object",
+			},
+			"objects_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The saved objects, as JSON array sorted by type and ID",
+			},
+			"snapshot_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The space, settings and saved objects, as JSON object",
+			},
+		},
+	}
+}
+
+func dataSourceKibanaSpaceExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+	includeSettings := d.Get("include_settings").(bool)
+	exportTypes := convertArrayInterfaceToArrayString(d.Get("export_types").(*schema.Set).List())
+
+	log.Debugf("Space: %s", space)
+	log.Debugf("Export types: %+v", exportTypes)
+
+	client := m.(*kibanaMeta).client
+
+	settings := map[string]any{}
+	if includeSettings {
+		settings, err = getKibanaSettings(client.Client, space)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	objects := make([]map[string]any, 0)
+	if len(exportTypes) > 0 {
+		data, err := client.API.KibanaSavedObject.Export(exportTypes, nil, false, space)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		objects, err = parseSnapshotNDJSON(string(data))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	objectsJSON, err := json.Marshal(objects)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	snapshotJSON, err := json.Marshal(map[string]any{
+		"space":    space,
+		"settings": settings,
+		"objects":  objects,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(space)
+	if err = d.Set("settings_json", string(settingsJSON)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("objects_json", string(objectsJSON)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("snapshot_json", string(snapshotJSON)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Export space %s successfully", space)
+
+	return nil
+}
+
+// parseSnapshotNDJSON permit to convert exported NDJSON as stable list of objects, without volatile fields
+func parseSnapshotNDJSON(data string) ([]map[string]any, error) {
+	objects := make([]map[string]any, 0)
+	for _, line := range splitNDJSON(data) {
+		object := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &object); err != nil {
+			return nil, fmt.Errorf("Error when unmarshal object %s: %w", line, err)
+		}
+		for _, field := range volatileSavedObjectFields {
+			delete(object, field)
+		}
+		objects = append(objects, object)
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		return fmt.Sprintf("%v/%v", objects[i]["type"], objects[i]["id"]) < fmt.Sprintf("%v/%v", objects[j]["type"], objects[j]["id"])
+	})
+
+	return objects, nil
+}
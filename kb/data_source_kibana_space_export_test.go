@@ -0,0 +1,55 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaSpaceExport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaSpaceExport,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kibana_space_export.test", "settings_json"),
+					resource.TestCheckResourceAttrSet("data.kibana_space_export.test", "objects_json"),
+					resource.TestCheckResourceAttrSet("data.kibana_space_export.test", "snapshot_json"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseSnapshotNDJSON(t *testing.T) {
+	data := `{"id":"b","type":"dashboard","version":"WzEsMV0=","updated_at":"2022-01-01T00:00:00Z","attributes":{"title":"b"}}
+{"id":"a","type":"dashboard","coreMigrationVersion":"8.5.0","attributes":{"title":"a"}}
+`
+	objects, err := parseSnapshotNDJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("Expected 2 objects, got %d", len(objects))
+	}
+	if objects[0]["id"] != "a" || objects[1]["id"] != "b" {
+		t.Errorf("Expected objects sorted by ID, got %+v", objects)
+	}
+	for _, object := range objects {
+		for _, field := range volatileSavedObjectFields {
+			if _, ok := object[field]; ok {
+				t.Errorf("Expected field %s removed, got %+v", field, object)
+			}
+		}
+	}
+}
+
+var testDataSourceKibanaSpaceExport = `
+data "kibana_space_export" "test" {
+  export_types = ["index-pattern", "dashboard"]
+}
+`
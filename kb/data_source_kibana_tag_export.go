@@ -0,0 +1,156 @@
+// Export the saved objects that have tags as NDJSON
+// API documentation: https://www.elastic.co/guide/en/kibana/master/saved-objects-api-export.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultKibanaTagExportTypes are the saved object types exported when export_types is not set
+var defaultKibanaTagExportTypes = []string{"dashboard", "visualization", "lens", "search", "map"}
+
+func dataSourceKibanaTagExport() *schema.Resource {
+	return &schema.Resource{
+		Description: "`kibana_tag_export` can be used to export, as NDJSON, all saved objects that have one of tags, to promote or backup them by tag.",
+		ReadContext: dataSourceKibanaTagExportRead,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
+				Description: "The space to export",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The name of tags. The saved objects that have one of them are exported",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"export_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The saved object types to export. Default to dashboard, visualization, lens, search and map",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"deep": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Export also the saved objects referenced by exported objects, like data views of dashboards",
+			},
+			"ndjson": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The saved objects, as NDJSON sorted by type and ID",
+			},
+			"object_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The exported saved objects, as type/id",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKibanaTagExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var err error
+	space := d.Get("space").(string)
+	tags := convertArrayInterfaceToArrayString(d.Get("tags").(*schema.Set).List())
+	exportTypes := convertArrayInterfaceToArrayString(d.Get("export_types").(*schema.Set).List())
+	if len(exportTypes) == 0 {
+		exportTypes = defaultKibanaTagExportTypes
+	}
+	sort.Strings(tags)
+
+	log.Debugf("Space: %s", space)
+	log.Debugf("Tags: %+v", tags)
+	log.Debugf("Export types: %+v", exportTypes)
+
+	client := m.(*kibanaMeta).client
+
+	tagObjects, err := findAllSavedObjects(client, "tag", space, &kbapi.OptionalFindParameters{
+		Fields: []string{"name"},
+	})
+	if err != nil {
+		return handleAPIError(err, "find tags")
+	}
+	references, err := buildKibanaTagReferences(tagObjects, tags)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data, err := exportKibanaSavedObjectsByReferences(client.Client, space, exportTypes, references, d.Get("deep").(bool))
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("export saved objects with tags %s", strings.Join(tags, ", ")))
+	}
+	objects, err := parseSnapshotNDJSON(string(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lines := make([]string, 0, len(objects))
+	ids := make([]string, 0, len(objects))
+	for _, object := range objects {
+		line, err := json.Marshal(object)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		lines = append(lines, string(line))
+		ids = append(ids, fmt.Sprintf("%v/%v", object["type"], object["id"]))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", space, strings.Join(tags, ",")))
+	if err = d.Set("ndjson", strings.Join(lines, "\n")); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("object_ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Export %d saved objects with tags %s successfully", len(objects), strings.Join(tags, ", "))
+
+	return nil
+}
+
+// buildKibanaTagReferences permit to convert the tag names as references to tag objects. It fail when tag not exist
+func buildKibanaTagReferences(tagObjects []map[string]any, names []string) ([]kibanaSavedObjectReference, error) {
+	tagIDs := make(map[string][]string, len(tagObjects))
+	for _, tagObject := range tagObjects {
+		attributes, _ := tagObject["attributes"].(map[string]any)
+		name, _ := attributes["name"].(string)
+		tagIDs[name] = append(tagIDs[name], tagObject["id"].(string))
+	}
+
+	references := make([]kibanaSavedObjectReference, 0, len(names))
+	for _, name := range names {
+		ids, ok := tagIDs[name]
+		if !ok {
+			return nil, fmt.Errorf("Tag %s not found", name)
+		}
+		for _, id := range ids {
+			references = append(references, kibanaSavedObjectReference{Type: "tag", ID: id})
+		}
+	}
+
+	return references, nil
+}
@@ -0,0 +1,69 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKibanaTagExport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaTagExport,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kibana_tag_export.test", "object_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.kibana_tag_export.test", "object_ids.0", "dashboard/terraform-test-tag-export"),
+					resource.TestCheckResourceAttrSet("data.kibana_tag_export.test", "ndjson"),
+				),
+			},
+		},
+	})
+}
+
+func TestBuildKibanaTagReferences(t *testing.T) {
+	tagObjects := []map[string]any{
+		{"id": "tag-1", "type": "tag", "attributes": map[string]any{"name": "team-a"}},
+		{"id": "tag-2", "type": "tag", "attributes": map[string]any{"name": "team-b"}},
+	}
+
+	references, err := buildKibanaTagReferences(tagObjects, []string{"team-b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(references) != 1 || references[0].Type != "tag" || references[0].ID != "tag-2" {
+		t.Errorf("Unexpected references: %+v", references)
+	}
+
+	if _, err = buildKibanaTagReferences(tagObjects, []string{"team-a", "team-c"}); err == nil {
+		t.Error("Expected error when tag not exist")
+	}
+}
+
+var testDataSourceKibanaTagExport = `
+resource kibana_object "test" {
+  name  = "terraform-test-tag-export"
+  data  = <<EOT
+{"attributes":{"name":"terraform-test-tag-export","color":"#aabbcc","description":""},"id":"terraform-test-tag-export","type":"tag"}
+{"attributes":{"title":"terraform-test-tag-export","panelsJSON":"[]"},"id":"terraform-test-tag-export","type":"dashboard","references":[{"id":"terraform-test-tag-export","name":"tag-ref-terraform-test-tag-export","type":"tag"}]}
+EOT
+  export_objects {
+    id   = "terraform-test-tag-export"
+    type = "tag"
+  }
+  export_objects {
+    id   = "terraform-test-tag-export"
+    type = "dashboard"
+  }
+}
+
+data "kibana_tag_export" "test" {
+  tags = ["terraform-test-tag-export"]
+
+  depends_on = [kibana_object.test]
+}
+`
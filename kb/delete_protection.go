@@ -0,0 +1,60 @@
+package kb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// savedObjectTypeByResource is the saved object type deleted by each resource.
+// The resources that never delete saved objects, like kibana_object, are not listed.
+var savedObjectTypeByResource = map[string]string{
+	"kibana_user_space":                     "space",
+	"kibana_infra_custom_dashboard":         kibanaInfraCustomDashboardSavedObjectType,
+	"kibana_logs_view":                      kibanaLogViewSavedObjectType,
+	"kibana_metrics_source":                 kibanaMetricsSourceSavedObjectType,
+	"kibana_timeline":                       "siem-ui-timeline",
+	"kibana_case":                           "cases",
+	"kibana_log_threshold_rule":             "alert",
+	"kibana_index_threshold_rule":           "alert",
+	"kibana_es_query_rule":                  "alert",
+	"kibana_anomaly_detection_alert_rule":   "alert",
+	"kibana_synthetics_monitor_status_rule": "alert",
+	"kibana_synthetics_tls_rule":            "alert",
+	"kibana_apm_latency_rule":               "alert",
+	"kibana_apm_error_rate_rule":            "alert",
+	"kibana_apm_anomaly_rule":               "alert",
+}
+
+// protectResource wrap the Delete function of resource, to only remove it from state when its saved object type is protected
+func protectResource(resourceType string, r *schema.Resource) *schema.Resource {
+	objectType, ok := savedObjectTypeByResource[resourceType]
+	if !ok || r.DeleteContext == nil {
+		return r
+	}
+
+	deleteContext := r.DeleteContext
+	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if !meta.(*kibanaMeta).protectedTypes[objectType] {
+			return deleteContext(ctx, d, meta)
+		}
+
+		id := d.Id()
+		d.SetId("")
+		setTrackedOperation(ctx, "forgotten")
+
+		log.Warnf("%s %s is protected by saved object type %s - just removing from state", resourceType, id, objectType)
+		fmt.Printf("[WARN] %s %s is protected by saved object type %s - just removing from state", resourceType, id, objectType)
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s %s not deleted from Kibana", resourceType, id),
+			Detail:   fmt.Sprintf("The saved object type %s is on protected_saved_object_types, so it is just removed from state. Delete it from Kibana if needed.", objectType),
+		}}
+	}
+
+	return r
+}
@@ -0,0 +1,70 @@
+package kb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProtectResource(t *testing.T) {
+	nbDeletes := 0
+	r := protectResource("kibana_user_space", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			nbDeletes++
+			d.SetId("")
+			return nil
+		},
+	})
+
+	// Protected type
+	d := r.TestResourceData()
+	d.SetId("team-a")
+	diags := r.DeleteContext(context.Background(), d, &kibanaMeta{protectedTypes: map[string]bool{"space": true}})
+	if nbDeletes != 0 {
+		t.Error("Expected user space not deleted")
+	}
+	if d.Id() != "" || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("Expected user space removed from state with warning, got %s %+v", d.Id(), diags)
+	}
+
+	// Not protected type
+	d = r.TestResourceData()
+	d.SetId("team-a")
+	diags = r.DeleteContext(context.Background(), d, &kibanaMeta{protectedTypes: map[string]bool{"alert": true}})
+	if nbDeletes != 1 || d.Id() != "" || len(diags) != 0 {
+		t.Errorf("Expected user space deleted, got %d deletes %+v", nbDeletes, diags)
+	}
+}
+
+func TestSavedObjectTypeByResource(t *testing.T) {
+	provider := Provider()
+	for resourceType := range savedObjectTypeByResource {
+		if _, ok := provider.ResourcesMap[resourceType]; !ok {
+			t.Errorf("Resource %s not exist on provider", resourceType)
+		}
+	}
+
+	// The types are the saved object types stored by Kibana, that users set on protected_saved_object_types
+	expected := map[string]string{
+		"kibana_user_space":             "space",
+		"kibana_infra_custom_dashboard": "infrastructure-ui-custom-dashboard",
+		"kibana_logs_view":              "infrastructure-monitoring-log-view",
+		"kibana_metrics_source":         "infrastructure-ui-source",
+		"kibana_timeline":               "siem-ui-timeline",
+		"kibana_case":                   "cases",
+		"kibana_es_query_rule":          "alert",
+	}
+	for resourceType, objectType := range expected {
+		if savedObjectTypeByResource[resourceType] != objectType {
+			t.Errorf("Expected resource %s to protect saved object type %s, got %s", resourceType, objectType, savedObjectTypeByResource[resourceType])
+		}
+	}
+}
@@ -101,6 +101,7 @@ func suppressEquivalentNDJSON(k, old, new string, d *schema.ResourceData) bool {
 		"migrationVersion":     nil,
 		"references":           nil,
 		"sort":                 nil,
+		"managed":              nil,
 	}
 
 	// NDJSON mean sthat each line correspond to JSON struct
@@ -0,0 +1,88 @@
+package kb
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// apiErrorKind is the family of error returned by Kibana API
+type apiErrorKind int
+
+const (
+	apiErrorUnknown   apiErrorKind = iota // Error not returned by Kibana or not handled
+	apiErrorNotFound                      // Object not exist on Kibana
+	apiErrorForbidden                     // User not allowed to do the action
+	apiErrorRetryable                     // Transient error, the same call can succeed later
+)
+
+// classifyAPIError return the family of error.
+// It use errors.As so it never panic when the error is not an kbapi.APIError (network failure, etc.)
+func classifyAPIError(err error) apiErrorKind {
+	if err == nil {
+		return apiErrorUnknown
+	}
+
+	var apiErr kbapi.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case 404:
+			return apiErrorNotFound
+		case 401, 403:
+			return apiErrorForbidden
+		case 408, 409, 429, 502, 503, 504:
+			return apiErrorRetryable
+		}
+		return apiErrorUnknown
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return apiErrorRetryable
+	}
+
+	return apiErrorUnknown
+}
+
+// isAPIErrorNotFound return true if Kibana said the object not exist
+func isAPIErrorNotFound(err error) bool {
+	return classifyAPIError(err) == apiErrorNotFound
+}
+
+// handleAPIError permit to convert error returned by Kibana API on diagnostics.
+// The action describe what the provider tried to do, like "delete role foo".
+func handleAPIError(err error, action string) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	switch classifyAPIError(err) {
+	case apiErrorNotFound:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to %s: object not found", action),
+			Detail:   err.Error(),
+		}}
+	case apiErrorForbidden:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to %s: permission denied", action),
+			Detail:   fmt.Sprintf("%s\nCheck the privileges of the user used by the provider.", err.Error()),
+		}}
+	case apiErrorRetryable:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to %s: Kibana is temporarily unavailable", action),
+			Detail:   fmt.Sprintf("%s\nThis error is transient, run apply again.", err.Error()),
+		}}
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Failed to %s", action),
+		Detail:   err.Error(),
+	}}
+}
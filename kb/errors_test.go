@@ -0,0 +1,52 @@
+package kb
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+)
+
+func TestClassifyAPIError(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected apiErrorKind
+	}{
+		"nil":         {err: nil, expected: apiErrorUnknown},
+		"not found":   {err: kbapi.NewAPIError(404, "404 Not Found"), expected: apiErrorNotFound},
+		"wrapped":     {err: fmt.Errorf("delete: %w", kbapi.NewAPIError(404, "404 Not Found")), expected: apiErrorNotFound},
+		"forbidden":   {err: kbapi.NewAPIError(403, "403 Forbidden"), expected: apiErrorForbidden},
+		"unavailable": {err: kbapi.NewAPIError(503, "503 Service Unavailable"), expected: apiErrorRetryable},
+		"bad request": {err: kbapi.NewAPIError(400, "400 Bad Request"), expected: apiErrorUnknown},
+		"timeout":     {err: &net.DNSError{IsTimeout: true}, expected: apiErrorRetryable},
+		"network":     {err: errors.New("connection refused"), expected: apiErrorUnknown},
+	}
+
+	for name, testCase := range testCases {
+		if kind := classifyAPIError(testCase.err); kind != testCase.expected {
+			t.Errorf("%s: expected %d, got %d", name, testCase.expected, kind)
+		}
+	}
+}
+
+func TestHandleAPIError(t *testing.T) {
+	if diags := handleAPIError(nil, "delete role foo"); diags != nil {
+		t.Errorf("Expected no diagnostics, got %+v", diags)
+	}
+
+	// Must not panic with error that is not an APIError
+	diags := handleAPIError(errors.New("connection refused"), "delete role foo")
+	if !diags.HasError() {
+		t.Fatal("Expected error diagnostic")
+	}
+	if diags[0].Summary != "Failed to delete role foo" {
+		t.Errorf("Unexpected summary: %s", diags[0].Summary)
+	}
+
+	diags = handleAPIError(kbapi.NewAPIError(403, "403 Forbidden"), "delete role foo")
+	if diags[0].Summary != "Failed to delete role foo: permission denied" {
+		t.Errorf("Unexpected summary: %s", diags[0].Summary)
+	}
+}
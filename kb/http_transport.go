@@ -0,0 +1,80 @@
+package kb
+
+import (
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// tuneTransport permit to set the keep-alive pool and compression of HTTP client.
+// The client is shared by all resources, so connexions are reused across the apply.
+// It must be called before replacing the transport, like on mock mode.
+func tuneTransport(c *resty.Client, maxIdleConnsPerHost int, idleConnTimeout time.Duration, compression bool) error {
+	transport, ok := c.GetClient().Transport.(*http.Transport)
+	if !ok {
+		return errors.Errorf("Transport of HTTP client is not *http.Transport: %T", c.GetClient().Transport)
+	}
+
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	if transport.MaxIdleConns != 0 && transport.MaxIdleConns < maxIdleConnsPerHost {
+		transport.MaxIdleConns = maxIdleConnsPerHost
+	}
+	transport.IdleConnTimeout = idleConnTimeout
+	transport.DisableCompression = !compression
+
+	return nil
+}
+
+// waitKibanaAvailable permit to retry requests while Kibana is not available, like during rolling upgrade or saved objects migration.
+// Kibana answer 503 until it is ready, and the connexion is refused while the node restart.
+// The requests are retried each interval until the window is over, so the apply is paused instead of failing halfway.
+func waitKibanaAvailable(c *resty.Client, window time.Duration, interval time.Duration) {
+	if window <= 0 || interval <= 0 {
+		return
+	}
+
+	c.SetRetryCount(int((window + interval - 1) / interval)).
+		SetRetryWaitTime(interval).
+		SetRetryMaxWaitTime(interval).
+		SetRetryAfter(func(c *resty.Client, resp *resty.Response) (time.Duration, error) {
+			return interval, nil
+		}).
+		AddRetryCondition(isKibanaUnavailable).
+		AddRetryHook(func(resp *resty.Response, err error) {
+			if err != nil {
+				log.Warnf("Kibana is not available (%s), wait %s before retry", err.Error(), interval)
+				return
+			}
+			log.Warnf("Kibana is not available (%s), wait %s before retry %s %s", resp.Status(), interval, resp.Request.Method, resp.Request.URL)
+		})
+}
+
+// isKibanaUnavailable return true when Kibana is restarting or not ready.
+// On error, it's only retried when the connexion can't be opened, because the request, like a create, can already be handled by Kibana
+func isKibanaUnavailable(resp *resty.Response, err error) bool {
+	if err != nil {
+		return isKibanaConnectionRefused(err)
+	}
+
+	return resp != nil && resp.StatusCode() == http.StatusServiceUnavailable
+}
+
+// isKibanaConnectionRefused return true when the connexion to Kibana can't be opened, so the request is not sent.
+// DNS errors are not retried, because it's a misconfiguration and not a restart
+func isKibanaConnectionRefused(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,137 @@
+package kb
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestTuneTransport(t *testing.T) {
+	client := resty.New()
+	if err := tuneTransport(client, 200, 30*time.Second, false); err != nil {
+		t.Fatal(err)
+	}
+
+	transport := client.GetClient().Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 200 {
+		t.Errorf("Expected 200 idle connexions per host, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxIdleConns < 200 {
+		t.Errorf("Expected at least 200 idle connexions, got %d", transport.MaxIdleConns)
+	}
+	if transport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("Expected idle timeout of 30s, got %s", transport.IdleConnTimeout)
+	}
+	if !transport.DisableCompression {
+		t.Error("Expected compression disabled")
+	}
+
+	// Transport replaced, like on mock mode
+	client.SetTransport(&mockTransport{})
+	if err := tuneTransport(client, 10, 90*time.Second, true); err == nil {
+		t.Error("Expected error when transport is not *http.Transport")
+	}
+}
+
+func TestWaitKibanaAvailable(t *testing.T) {
+	nbCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nbCalls++
+		if nbCalls <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Kibana available again before the window is over
+	client := resty.New()
+	waitKibanaAvailable(client, 50*time.Millisecond, 10*time.Millisecond)
+	resp, err := client.R().Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode() != http.StatusOK || nbCalls != 3 {
+		t.Errorf("Expected 200 after 3 calls, got %d after %d calls", resp.StatusCode(), nbCalls)
+	}
+
+	// Kibana still not available when the window is over
+	nbCalls = -10
+	client = resty.New()
+	waitKibanaAvailable(client, 20*time.Millisecond, 10*time.Millisecond)
+	resp, err = client.R().Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode() != http.StatusServiceUnavailable || nbCalls != -7 {
+		t.Errorf("Expected 503 after 3 calls, got %d after %d calls", resp.StatusCode(), nbCalls+10)
+	}
+
+	// Disabled
+	nbCalls = 0
+	client = resty.New()
+	waitKibanaAvailable(client, 0, 10*time.Millisecond)
+	resp, err = client.R().Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode() != http.StatusServiceUnavailable || nbCalls != 1 {
+		t.Errorf("Expected 503 without retry, got %d after %d calls", resp.StatusCode(), nbCalls)
+	}
+}
+
+func TestWaitKibanaAvailableOnError(t *testing.T) {
+	// Create not retried when the response is not received, because Kibana can already handle it
+	var nbCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&nbCalls, 1)
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := resty.New().SetTimeout(20 * time.Millisecond)
+	waitKibanaAvailable(client, 50*time.Millisecond, 10*time.Millisecond)
+	if _, err := client.R().SetBody(map[string]any{"name": "test"}).Post(server.URL); err == nil {
+		t.Fatal("Expected timeout error")
+	}
+	if n := atomic.LoadInt32(&nbCalls); n != 1 {
+		t.Errorf("Expected POST not retried on read timeout, got %d calls", n)
+	}
+
+	// Retried when the connexion is refused, like when Kibana restart
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "http://" + listener.Addr().String()
+	listener.Close()
+
+	nbRetries := 0
+	client = resty.New()
+	waitKibanaAvailable(client, 20*time.Millisecond, 10*time.Millisecond)
+	client.AddRetryHook(func(resp *resty.Response, err error) {
+		nbRetries++
+	})
+	if _, err = client.R().SetBody(map[string]any{"name": "test"}).Post(url); err == nil {
+		t.Fatal("Expected connexion refused error")
+	}
+	if nbRetries < 2 {
+		t.Errorf("Expected retries when connexion is refused, got %d", nbRetries)
+	}
+
+	// DNS and TLS errors are not retried
+	if isKibanaUnavailable(nil, &net.DNSError{Err: "no such host", Name: "kibana.invalid"}) {
+		t.Error("Expected DNS error not retried")
+	}
+	if isKibanaUnavailable(nil, errors.New("x509: certificate signed by unknown authority")) {
+		t.Error("Expected TLS error not retried")
+	}
+}
@@ -0,0 +1,87 @@
+package kb
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultLogBodyLimit is the default number of bytes of API bodies kept on debug logs
+const defaultLogBodyLimit = 8192
+
+var (
+	logBodyHookOnce sync.Once
+	logBodyHook     = &kibanaLogBodyHook{}
+
+	// logBodyPrefixes are the prefixes of debug messages that log API request and response bodies, by provider and go-kibana-rest
+	logBodyPrefixes = []string{"Response: ", "Data response: ", "Data: ", "data: ", "Data to import: "}
+)
+
+// kibanaLogBodyHook truncate the debug messages of API bodies longer than limit, like API responses with large rule params.
+// The other debug messages are kept.
+// The logger is global, so the hook is added one time and its limit is updated when provider is configured
+type kibanaLogBodyHook struct {
+	limit int64
+}
+
+// Levels return the levels where messages are truncated
+func (h *kibanaLogBodyHook) Levels() []log.Level {
+	return []log.Level{log.DebugLevel}
+}
+
+// Fire truncate the message of log entry when it's an API body
+func (h *kibanaLogBodyHook) Fire(entry *log.Entry) error {
+	if isLogBody(entry.Message) {
+		entry.Message = truncateLogBody(entry.Message, int(atomic.LoadInt64(&h.limit)))
+	}
+	return nil
+}
+
+// isLogBody return true when the debug message log API request or response body
+func isLogBody(message string) bool {
+	for _, prefix := range logBodyPrefixes {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setLogBodyLimit permit to set the number of bytes of API bodies kept on debug messages. 0 disable the truncation
+func setLogBodyLimit(limit int) {
+	logBodyHookOnce.Do(func() {
+		log.AddHook(logBodyHook)
+	})
+	atomic.StoreInt64(&logBodyHook.limit, int64(limit))
+}
+
+// truncateLogBody return the message cut to limit bytes, without splitting UTF-8 character, with the number of bytes removed
+func truncateLogBody(message string, limit int) string {
+	if limit <= 0 || len(message) <= limit {
+		return message
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+
+	return fmt.Sprintf("%s... (%d bytes truncated, set log_body_limit to 0 or TF_LOG to TRACE to log the full body)", message[:cut], len(message)-cut)
+}
+
+// isTraceLogEnabled return true when Terraform log the provider at TRACE level
+func isTraceLogEnabled() bool {
+	for _, env := range []string{"TF_LOG_PROVIDER", "TF_LOG"} {
+		if level := os.Getenv(env); level != "" {
+			return strings.EqualFold(level, "TRACE")
+		}
+	}
+
+	return false
+}
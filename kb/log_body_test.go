@@ -0,0 +1,67 @@
+package kb
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestTruncateLogBody(t *testing.T) {
+	if message := truncateLogBody("short", 10); message != "short" {
+		t.Errorf("Expected short message kept, got %s", message)
+	}
+	if message := truncateLogBody(strings.Repeat("a", 100), 0); len(message) != 100 {
+		t.Errorf("Expected message kept when limit is 0, got %d bytes", len(message))
+	}
+
+	message := truncateLogBody(strings.Repeat("a", 100), 10)
+	if !strings.HasPrefix(message, strings.Repeat("a", 10)+"... (90 bytes truncated") {
+		t.Errorf("Expected message cut to 10 bytes, got %s", message)
+	}
+
+	// é is 2 bytes, so it must not be split
+	message = truncateLogBody("aé"+strings.Repeat("b", 10), 2)
+	if !strings.HasPrefix(message, "a... (12 bytes truncated") {
+		t.Errorf("Expected message cut before UTF-8 character, got %s", message)
+	}
+}
+
+func TestKibanaLogBodyHook(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&buf)
+	logger.SetLevel(log.DebugLevel)
+	logger.AddHook(&kibanaLogBodyHook{limit: 10})
+
+	logger.Debug("Response: ", strings.Repeat("a", 100))
+	logger.Info(strings.Repeat("b", 100))
+	logger.Debugf("Planned rules: %s", strings.Repeat("c", 100))
+
+	if strings.Contains(buf.String(), strings.Repeat("a", 20)) {
+		t.Errorf("Expected debug message truncated, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), strings.Repeat("b", 100)) {
+		t.Errorf("Expected info message kept, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), strings.Repeat("c", 100)) {
+		t.Errorf("Expected debug message that is not an API body kept, got %s", buf.String())
+	}
+}
+
+func TestIsTraceLogEnabled(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER", "")
+	t.Setenv("TF_LOG", "DEBUG")
+	if isTraceLogEnabled() {
+		t.Error("Expected trace disabled with TF_LOG=DEBUG")
+	}
+	t.Setenv("TF_LOG", "trace")
+	if !isTraceLogEnabled() {
+		t.Error("Expected trace enabled with TF_LOG=trace")
+	}
+	t.Setenv("TF_LOG_PROVIDER", "INFO")
+	if isTraceLogEnabled() {
+		t.Error("Expected TF_LOG_PROVIDER to take precedence")
+	}
+}
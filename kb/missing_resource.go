@@ -0,0 +1,39 @@
+package kb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// checkMissingResource wrap the Read function of resource, to fail when the object is not found on Kibana and treat_missing_as_error is set.
+// By default, Read remove the object not found from state, so it's silently created again on next apply.
+func checkMissingResource(resourceType string, r *schema.Resource) *schema.Resource {
+	readContext := r.ReadContext
+	if readContext == nil {
+		return r
+	}
+
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		id := d.Id()
+		// Skipped rule is removed from state on purpose, to be created when it's supported
+		_, isSkipped := d.GetOk("skipped")
+
+		diags := readContext(ctx, d, meta)
+		if diags.HasError() || id == "" || d.Id() != "" || isSkipped || !meta.(*kibanaMeta).treatMissingAsError {
+			return diags
+		}
+
+		d.SetId(id)
+
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s %s not found on Kibana", resourceType, id),
+			Detail:   "The object was probably deleted outside of Terraform. Create it again on Kibana, or remove it from state with `terraform state rm`, then apply again.",
+		})
+	}
+
+	return r
+}
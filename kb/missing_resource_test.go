@@ -0,0 +1,63 @@
+package kb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCheckMissingResource(t *testing.T) {
+	r := checkMissingResource("kibana_user_space", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			d.SetId("")
+			return nil
+		},
+	})
+
+	// Removed from state by default
+	d := r.TestResourceData()
+	d.SetId("team-a")
+	diags := r.ReadContext(context.Background(), d, &kibanaMeta{})
+	if d.Id() != "" || diags.HasError() {
+		t.Errorf("Expected user space removed from state, got %s %+v", d.Id(), diags)
+	}
+
+	// Failed when treat_missing_as_error
+	d = r.TestResourceData()
+	d.SetId("team-a")
+	diags = r.ReadContext(context.Background(), d, &kibanaMeta{treatMissingAsError: true})
+	if d.Id() != "team-a" || !diags.HasError() {
+		t.Errorf("Expected error and user space kept on state, got %s %+v", d.Id(), diags)
+	}
+
+	// Skipped rule is always removed from state
+	r = checkMissingResource("kibana_index_threshold_rule", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"skipped": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			d.SetId("")
+			return nil
+		},
+	})
+	d = r.TestResourceData()
+	d.SetId("default/skipped")
+	if err := d.Set("skipped", true); err != nil {
+		t.Fatal(err)
+	}
+	diags = r.ReadContext(context.Background(), d, &kibanaMeta{treatMissingAsError: true})
+	if d.Id() != "" || diags.HasError() {
+		t.Errorf("Expected skipped rule removed from state, got %s %+v", d.Id(), diags)
+	}
+}
@@ -0,0 +1,100 @@
+package kb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// mockEndpoint is a recorded API call served when the provider run in mock mode
+type mockEndpoint struct {
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	StatusCode int             `json:"status_code"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// mockTransport serve the recorded API calls instead of contacting Kibana
+type mockTransport struct {
+	endpoints []mockEndpoint
+}
+
+// newMockTransport permit to load the recorded API calls from file
+func newMockTransport(file string) (*mockTransport, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]mockEndpoint, 0)
+	if err = json.Unmarshal(data, &endpoints); err != nil {
+		return nil, fmt.Errorf("Error when read mock endpoints file %s: %w", file, err)
+	}
+
+	for i := range endpoints {
+		if endpoints[i].Method == "" {
+			endpoints[i].Method = http.MethodGet
+		}
+		if endpoints[i].StatusCode == 0 {
+			endpoints[i].StatusCode = http.StatusOK
+		}
+	}
+
+	return &mockTransport{
+		endpoints: endpoints,
+	}, nil
+}
+
+// RoundTrip return the first recorded API call that match the method and the path.
+// If the recorded path contain query string, it must match too.
+// It return 404 when no recorded API call match.
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, endpoint := range t.endpoints {
+		if !strings.EqualFold(endpoint.Method, req.Method) {
+			continue
+		}
+		path := req.URL.Path
+		if strings.Contains(endpoint.Path, "?") {
+			path = req.URL.RequestURI()
+		}
+		if endpoint.Path != path {
+			continue
+		}
+
+		log.Debugf("Mock endpoint %s %s", endpoint.Method, endpoint.Path)
+		return newMockResponse(req, endpoint.StatusCode, endpoint.Body), nil
+	}
+
+	log.Debugf("No mock endpoint for %s %s", req.Method, req.URL.RequestURI())
+	return newMockResponse(req, http.StatusNotFound, json.RawMessage(`{"statusCode":404,"error":"Not Found","message":"Not Found"}`)), nil
+}
+
+// newMockResponse build the HTTP response.
+// When body is a JSON string, it's send as is, to permit to mock NDJSON body
+func newMockResponse(req *http.Request, statusCode int, body json.RawMessage) *http.Response {
+	var content []byte
+	var s string
+	if err := json.Unmarshal(body, &s); err == nil {
+		content = []byte(s)
+	} else {
+		content = body
+	}
+
+	return &http.Response{
+		StatusCode:    statusCode,
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(content)),
+		ContentLength: int64(len(content)),
+		Request:       req,
+	}
+}
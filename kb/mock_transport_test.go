@@ -0,0 +1,52 @@
+package kb
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProviderMockEndpoints(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":                 "http://kibana.mock:5601",
+		"mock_endpoints_file": path + "/../fixtures/mock-endpoints.json",
+	}))
+	if diags.HasError() {
+		t.Fatalf("err: %+v", diags)
+	}
+
+	meta := provider.Meta().(*kibanaMeta)
+	if meta.version != "8.5.0" {
+		t.Errorf("Expected version 8.5.0, got %s", meta.version)
+	}
+	if meta.username != "elastic" {
+		t.Errorf("Expected username elastic, got %s", meta.username)
+	}
+	if meta.license == nil || meta.license.Type != "platinum" {
+		t.Errorf("Expected platinum license, got %+v", meta.license)
+	}
+
+	userSpace, err := meta.client.API.KibanaSpaces.Get("terraform-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userSpace == nil || userSpace.Name != "terraform-test" {
+		t.Errorf("Expected user space terraform-test, got %+v", userSpace)
+	}
+
+	userSpace, err = meta.client.API.KibanaSpaces.Get("not-recorded")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userSpace != nil {
+		t.Errorf("Expected not found user space, got %+v", userSpace)
+	}
+}
@@ -0,0 +1,122 @@
+package kb
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// namedResources is the resources whose objects are identified by name on Kibana UI, like rules.
+// Two objects with the same name in the same space are often a copy-paste error.
+var namedResources = map[string]bool{
+	"kibana_log_threshold_rule":             true,
+	"kibana_index_threshold_rule":           true,
+	"kibana_es_query_rule":                  true,
+	"kibana_anomaly_detection_alert_rule":   true,
+	"kibana_synthetics_monitor_status_rule": true,
+	"kibana_synthetics_tls_rule":            true,
+	"kibana_apm_latency_rule":               true,
+	"kibana_apm_error_rate_rule":            true,
+	"kibana_apm_anomaly_rule":               true,
+}
+
+// kibanaNameRegistry keep the names of objects planned during the current run, by space
+type kibanaNameRegistry struct {
+	mutex sync.Mutex
+	names map[string][]kibanaNamedObject
+	nbNew int
+}
+
+// kibanaNamedObject is one object planned with a name
+type kibanaNamedObject struct {
+	id          string
+	description string
+	changed     bool
+}
+
+// newKibanaNameRegistry return new empty name registry
+func newKibanaNameRegistry() *kibanaNameRegistry {
+	return &kibanaNameRegistry{
+		names: map[string][]kibanaNamedObject{},
+	}
+}
+
+// register permit to add the name of object planned in space, and return error when another object of the plan already has this name.
+// Names are compared case insensitive and with collapsed spaces, like users read them.
+// The objects not yet created have empty ID, they are all different objects.
+// Objects already existing with the same name are only reported when one of them is created or renamed
+func (r *kibanaNameRegistry) register(space string, name string, id string, description string, changed bool) error {
+	if r == nil {
+		return nil
+	}
+
+	key := fmt.Sprintf("%s/%s", space, strings.ToLower(strings.Join(strings.Fields(name), " ")))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if id == "" {
+		r.nbNew++
+		id = fmt.Sprintf("new-%d", r.nbNew)
+	}
+	for _, other := range r.names[key] {
+		if other.id == id || (!changed && !other.changed) {
+			continue
+		}
+		return fmt.Errorf("name %q is used by %s and %s in space %s. Rename one of them, it's often a copy-paste error. "+
+			"The provider can't know the resource addresses, so search the blocks by their ID, or by their tags for new ones", name, other.description, description, space)
+	}
+	r.names[key] = append(r.names[key], kibanaNamedObject{id: id, description: description, changed: changed})
+
+	return nil
+}
+
+// describeNewKibanaNamedObject return the description of object not yet created, to find its block on config.
+// The SDK not give the resource address to provider, so the tags and the hash of the config of block are used
+func describeNewKibanaNamedObject(resourceType string, tags []string, config cty.Value) string {
+	sort.Strings(tags)
+	hash := sha256.Sum256([]byte(config.GoString()))
+
+	return fmt.Sprintf("new %s (tags [%s], config hash %x)", resourceType, strings.Join(tags, ", "), hash[:4])
+}
+
+// detectNameCollisions wrap the CustomizeDiff function of resource, to fail plan when two objects have the same name in the same space
+func detectNameCollisions(resourceType string, r *schema.Resource) *schema.Resource {
+	if !namedResources[resourceType] {
+		return r
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, d, meta); err != nil {
+				return err
+			}
+		}
+
+		providerMeta, ok := meta.(*kibanaMeta)
+		if !ok || !d.NewValueKnown("space") || !d.NewValueKnown("name") {
+			return nil
+		}
+		description := fmt.Sprintf("%s %s", resourceType, d.Id())
+		if d.Id() == "" {
+			tags := []string{}
+			if d.NewValueKnown("tags") {
+				if rawTags, ok := d.Get("tags").(*schema.Set); ok {
+					tags = convertArrayInterfaceToArrayString(rawTags.List())
+				}
+			}
+			description = describeNewKibanaNamedObject(resourceType, tags, d.GetRawConfig())
+		}
+
+		return providerMeta.names.register(d.Get("space").(string), d.Get("name").(string), d.Id(), description, d.Id() == "" || d.HasChange("name"))
+	}
+
+	return r
+}
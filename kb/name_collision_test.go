@@ -0,0 +1,64 @@
+package kb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestKibanaNameRegistry(t *testing.T) {
+	var disabled *kibanaNameRegistry
+	if err := disabled.register("default", "test", "", "new kibana_es_query_rule", true); err != nil {
+		t.Errorf("Expected no check when registry is disabled, got %s", err)
+	}
+
+	registry := newKibanaNameRegistry()
+	if err := registry.register("default", "High CPU", "", "new kibana_index_threshold_rule", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.register("team-a", "High CPU", "", "new kibana_index_threshold_rule", true); err != nil {
+		t.Errorf("Expected same name in other space to be valid, got %s", err)
+	}
+	err := registry.register("default", "high  cpu", "", "new kibana_es_query_rule", true)
+	if err == nil {
+		t.Fatal("Expected error when two new rules have the same name in the same space")
+	}
+	if !strings.Contains(err.Error(), "new kibana_index_threshold_rule and new kibana_es_query_rule in space default") {
+		t.Errorf("Expected both resources on error, got %s", err.Error())
+	}
+
+	// Existing rules with the same name are kept as is
+	if err = registry.register("default", "Errors", "default/1", "kibana_es_query_rule default/1", false); err != nil {
+		t.Fatal(err)
+	}
+	if err = registry.register("default", "Errors", "default/2", "kibana_es_query_rule default/2", false); err != nil {
+		t.Errorf("Expected existing rules not reported, got %s", err)
+	}
+	if err = registry.register("default", "Errors", "default/2", "kibana_es_query_rule default/2", false); err != nil {
+		t.Errorf("Expected same rule planned again to be valid, got %s", err)
+	}
+	if err = registry.register("default", "Errors", "default/3", "kibana_es_query_rule default/3", true); err == nil {
+		t.Error("Expected error when rule is renamed with the name of another rule")
+	}
+}
+
+func TestDescribeNewKibanaNamedObject(t *testing.T) {
+	config := func(index string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"name":  cty.StringVal("High CPU"),
+			"index": cty.StringVal(index),
+		})
+	}
+
+	description := describeNewKibanaNamedObject("kibana_es_query_rule", []string{"team-b", "team-a"}, config("logs-*"))
+	if !strings.HasPrefix(description, "new kibana_es_query_rule (tags [team-a, team-b], config hash ") {
+		t.Errorf("Unexpected description: %s", description)
+	}
+	if description != describeNewKibanaNamedObject("kibana_es_query_rule", []string{"team-a", "team-b"}, config("logs-*")) {
+		t.Error("Expected same description for same config")
+	}
+	if description == describeNewKibanaNamedObject("kibana_es_query_rule", []string{"team-a", "team-b"}, config("metrics-*")) {
+		t.Error("Expected different description for copy-pasted blocks with different config")
+	}
+}
@@ -0,0 +1,75 @@
+package kb
+
+import (
+	"fmt"
+
+	kibana "github.com/disaster37/go-kibana-rest/v8"
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+)
+
+const (
+	defaultPerPage = 100 // Number of items asked by page when list objects
+)
+
+// pageFetcher permit to get one page of a paginated API.
+// It return the items of the page and the total number of items, or 0 when the API not give it
+type pageFetcher[T any] func(page int, perPage int) (items []T, total int, err error)
+
+// listAllPages permit to call paginated API until all items are fetched.
+// Kibana list API return only the first 20 items by default, so all plural data sources must use it.
+func listAllPages[T any](perPage int, fetch pageFetcher[T]) ([]T, error) {
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+
+	results := make([]T, 0)
+	for page := 1; ; page++ {
+		items, total, err := fetch(page, perPage)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, items...)
+
+		// Some API not return the total, so a full page only stop the loop when the total is known
+		if len(items) == 0 || len(items) < perPage || (total > 0 && len(results) >= total) {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// findAllSavedObjects permit to find all saved objects that match the parameters by walking all pages
+func findAllSavedObjects(client *kibana.Client, objectType string, space string, parameters *kbapi.OptionalFindParameters) ([]map[string]any, error) {
+	if parameters == nil {
+		parameters = &kbapi.OptionalFindParameters{}
+	}
+
+	return listAllPages(parameters.ObjectsPerPage, func(page int, perPage int) ([]map[string]any, int, error) {
+		parameters.Page = page
+		parameters.ObjectsPerPage = perPage
+		data, err := client.API.KibanaSavedObject.Find(objectType, space, parameters)
+		if err != nil {
+			return nil, 0, err
+		}
+		if data == nil {
+			return nil, 0, nil
+		}
+
+		rawObjects, ok := data["saved_objects"].([]any)
+		if !ok {
+			return nil, 0, fmt.Errorf("Unexpected find saved objects response: %+v", data)
+		}
+		objects := make([]map[string]any, 0, len(rawObjects))
+		for _, rawObject := range rawObjects {
+			objects = append(objects, rawObject.(map[string]any))
+		}
+
+		total := 0
+		if rawTotal, ok := data["total"].(float64); ok {
+			total = int(rawTotal)
+		}
+
+		return objects, total, nil
+	})
+}
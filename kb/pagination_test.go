@@ -0,0 +1,77 @@
+package kb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestListAllPages(t *testing.T) {
+	items := make([]int, 45)
+	for i := range items {
+		items[i] = i
+	}
+
+	// Fake API that paginate items
+	nbCalls := 0
+	fetch := func(page int, perPage int) ([]int, int, error) {
+		nbCalls++
+		start := (page - 1) * perPage
+		if start >= len(items) {
+			return []int{}, len(items), nil
+		}
+		end := start + perPage
+		if end > len(items) {
+			end = len(items)
+		}
+		return items[start:end], len(items), nil
+	}
+
+	results, err := listAllPages(20, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 45 {
+		t.Errorf("Expected 45 items, got %d", len(results))
+	}
+	if nbCalls != 3 {
+		t.Errorf("Expected 3 calls, got %d", nbCalls)
+	}
+
+	// Exact multiple of per page must not loop forever
+	nbCalls = 0
+	items = items[:40]
+	results, err = listAllPages(20, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 40 {
+		t.Errorf("Expected 40 items, got %d", len(results))
+	}
+	if nbCalls != 2 {
+		t.Errorf("Expected 2 calls, got %d", nbCalls)
+	}
+
+	// Full first page without total must fetch the next pages
+	nbCalls = 0
+	results, err = listAllPages(20, func(page int, perPage int) ([]int, int, error) {
+		items, _, err := fetch(page, perPage)
+		return items, 0, err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 40 {
+		t.Errorf("Expected 40 items without total, got %d", len(results))
+	}
+	if nbCalls != 3 {
+		t.Errorf("Expected 3 calls without total, got %d", nbCalls)
+	}
+
+	// Error must be returned
+	_, err = listAllPages(20, func(page int, perPage int) ([]int, int, error) {
+		return nil, 0, errors.New("boom")
+	})
+	if err == nil {
+		t.Error("Expected error")
+	}
+}
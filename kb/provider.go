@@ -2,7 +2,11 @@ package kb
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"os"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/coreos/go-semver/semver"
@@ -10,6 +14,7 @@ import (
 	"github.com/disaster37/go-kibana-rest/v8/kbapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	log "github.com/sirupsen/logrus"
@@ -17,9 +22,31 @@ import (
 
 var logEntry *logrus.Entry
 
+// Version is the provider version sent on User-Agent, set by main from build flags
+var Version = "dev"
+
+// kibanaMeta is the object shared with all resources and data sources
+// It contain the Kibana client and the informations fetched at configure time
+type kibanaMeta struct {
+	client              *kibana.Client
+	version             string
+	username            string
+	roles               []string
+	license             *kibanaLicense
+	tracker             *applyTracker
+	dryRun              bool
+	serverless          bool
+	connectors          *kibanaConnectorCache
+	protectedTypes      map[string]bool
+	ruleDeletes         *kibanaRuleDeleteBatcher
+	treatMissingAsError bool
+	ruleIntervalMin     time.Duration
+	names               *kibanaNameRegistry
+}
+
 // Provider define kibana provider
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"url": {
 				Type:        schema.TypeString,
@@ -36,13 +63,21 @@ func Provider() *schema.Provider {
 			"password": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("KIBANA_PASSWORD", nil),
 				Description: "Password to use to connect to Kibana using basic auth",
 			},
+			"api_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("KIBANA_API_KEY", nil),
+				Description: "API key (base64 encoded `id:api_key`) to use to connect to Kibana. It take precedence over basic auth",
+			},
 			"cacert_files": {
 				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "A Custom CA certificates path",
+				Description: "A Custom CA certificates path. Default to comma separated paths of environment variable KIBANA_CACERT",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -65,52 +100,220 @@ func Provider() *schema.Provider {
 				Default:     10,
 				Description: "Wait time in second before retry connexion",
 			},
+			"wait_until_available": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KIBANA_WAIT_UNTIL_AVAILABLE", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Time in second to wait Kibana to be available again when it is upgrading or in maintenance, retrying requests each wait_before_retry. 0 disable it",
+			},
 			"debug": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 				Description: "Set logger to debug on Elasticsearch client",
 			},
+			"log_body_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KIBANA_LOG_BODY_LIMIT", defaultLogBodyLimit),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The number of bytes of API bodies kept on debug logs. The full bodies are logged when TF_LOG is TRACE. 0 disable the truncation",
+			},
+			"max_idle_conns_per_host": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of keep-alive connexions kept open to Kibana",
+			},
+			"idle_conn_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      90,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Time in second an idle keep-alive connexion stay open. 0 means no limit",
+			},
+			"compression": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Request gzip compressed responses",
+			},
+			"mock_endpoints_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KIBANA_MOCK_ENDPOINTS_FILE", nil),
+				Description: "JSON file of recorded API calls to serve instead of contacting Kibana",
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KIBANA_USER_AGENT_SUFFIX", nil),
+				Description: "Text appended on User-Agent of all requests, to identify the pipeline on Kibana audit logs",
+			},
+			"rule_bulk_delete_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Delete rules with one bulk delete call when more than this number of rules are deleted together, like on destroy. It must be lower than Terraform -parallelism. 0 disable it",
+			},
+			"rule_bulk_delete_window": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum time in second to group the rule deletions before deleting them",
+			},
+			"schedule_interval_min": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KIBANA_SCHEDULE_INTERVAL_MIN", ""),
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[1-9][0-9]*[smhd]$`), "must be a duration like 30s"),
+				Description:  "The shortest interval allowed between rule executions, like 30s. Rules scheduled more frequently are rejected on plan",
+			},
+			"protected_saved_object_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Saved object types never deleted from Kibana, like `space` or `alert`. The resources of these types are just removed from state on destroy",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"metrics_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KIBANA_METRICS_FILE", nil),
+				Description: "JSON file where to write the count and latencies of operations done by apply",
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KIBANA_DRY_RUN", false),
+				Description: "Not create, update or delete Kibana objects, only log them and failed the apply with the summary",
+			},
+			"detect_name_collisions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KIBANA_DETECT_NAME_COLLISIONS", false),
+				Description: "Fail plan when two rules have the same name in the same space",
+			},
+			"treat_missing_as_error": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KIBANA_TREAT_MISSING_AS_ERROR", false),
+				Description: "Fail refresh when a Kibana object is not found, instead of removing it from state",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"kibana_user_space":        resourceKibanaUserSpace(),
-			"kibana_role":              resourceKibanaRole(),
-			"kibana_object":            resourceKibanaObject(),
-			"kibana_logstash_pipeline": resourceKibanaLogstashPipeline(),
-			"kibana_copy_object":       resourceKibanaCopyObject(),
+			"kibana_user_space":                     resourceKibanaUserSpace(),
+			"kibana_role":                           resourceKibanaRole(),
+			"kibana_object":                         resourceKibanaObject(),
+			"kibana_logstash_pipeline":              resourceKibanaLogstashPipeline(),
+			"kibana_copy_object":                    resourceKibanaCopyObject(),
+			"kibana_infra_custom_dashboard":         resourceKibanaInfraCustomDashboard(),
+			"kibana_observability_annotation":       resourceKibanaObservabilityAnnotation(),
+			"kibana_apm_index_settings":             resourceKibanaAPMIndexSettings(),
+			"kibana_logs_view":                      resourceKibanaLogsView(),
+			"kibana_metrics_source":                 resourceKibanaMetricsSource(),
+			"kibana_detection_rules_prepackaged":    resourceKibanaDetectionRulesPrepackaged(),
+			"kibana_detection_rule_bulk_action":     resourceKibanaDetectionRuleBulkAction(),
+			"kibana_timeline":                       resourceKibanaTimeline(),
+			"kibana_case":                           resourceKibanaCase(),
+			"kibana_log_threshold_rule":             resourceKibanaLogThresholdRule(),
+			"kibana_index_threshold_rule":           resourceKibanaIndexThresholdRule(),
+			"kibana_es_query_rule":                  resourceKibanaESQueryRule(),
+			"kibana_anomaly_detection_alert_rule":   resourceKibanaAnomalyDetectionAlertRule(),
+			"kibana_synthetics_monitor_status_rule": resourceKibanaSyntheticsMonitorStatusRule(),
+			"kibana_synthetics_tls_rule":            resourceKibanaSyntheticsTLSRule(),
+			"kibana_apm_latency_rule":               resourceKibanaAPMLatencyRule(),
+			"kibana_apm_error_rate_rule":            resourceKibanaAPMErrorRateRule(),
+			"kibana_apm_anomaly_rule":               resourceKibanaAPMAnomalyRule(),
+			"kibana_alerting_backup":                resourceKibanaAlertingBackup(),
+			"kibana_alerting_restore":               resourceKibanaAlertingRestore(),
+			"kibana_alerting_summary_digest":        resourceKibanaAlertingSummaryDigest(),
+			"kibana_spaces_disabled_features":       resourceKibanaSpacesDisabledFeatures(),
+			"kibana_default_data_view":              resourceKibanaDefaultDataView(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"kibana_host": dataSourceKibanaHost(),
+			"kibana_host":                          dataSourceKibanaHost(),
+			"kibana_provider_info":                 dataSourceKibanaProviderInfo(),
+			"kibana_alerting_global_execution_log": dataSourceKibanaAlertingGlobalExecutionLog(),
+			"kibana_license":                       dataSourceKibanaLicense(),
+			"kibana_space_export":                  dataSourceKibanaSpaceExport(),
+			"kibana_preconfigured_connector":       dataSourceKibanaPreconfiguredConnector(),
+			"kibana_connector_types":               dataSourceKibanaConnectorTypes(),
+			"kibana_saved_object_resolve":          dataSourceKibanaSavedObjectResolve(),
+			"kibana_rule_preview":                  dataSourceKibanaRulePreview(),
+			"kibana_orphaned_connectors":           dataSourceKibanaOrphanedConnectors(),
+			"kibana_references":                    dataSourceKibanaReferences(),
+			"kibana_dashboards":                    dataSourceKibanaDashboards(),
+			"kibana_data_views":                    dataSourceKibanaDataViews(),
+			"kibana_role_templates":                dataSourceKibanaRoleTemplates(),
+			"kibana_object_exists":                 dataSourceKibanaObjectExists(),
+			"kibana_tag_export":                    dataSourceKibanaTagExport(),
 		},
+	}
 
-		ConfigureContextFunc: providerConfigure,
+	// Terraform version is only known at configure time
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return providerConfigure(ctx, d, provider.UserAgent("terraform-provider-kibana", Version))
 	}
+
+	// Record all changes to summarize them when apply failed
+	for name, resource := range provider.ResourcesMap {
+		trackResource(name, protectResource(name, checkMissingResource(name, detectNameCollisions(name, resource))))
+	}
+
+	return provider
 }
 
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData, userAgent string) (interface{}, diag.Diagnostics) {
 
 	URL := d.Get("url").(string)
 	insecure := d.Get("insecure").(bool)
 	cacertFiles := convertArrayInterfaceToArrayString(d.Get("cacert_files").(*schema.Set).List())
 	username := d.Get("username").(string)
 	password := d.Get("password").(string)
+	apiKey := d.Get("api_key").(string)
 	retry := d.Get("retry").(int)
 	waitBeforeRetry := d.Get("wait_before_retry").(int)
+	waitUntilAvailable := d.Get("wait_until_available").(int)
 	debug := d.Get("debug").(bool)
+	logBodyLimit := d.Get("log_body_limit").(int)
+	mockEndpointsFile := d.Get("mock_endpoints_file").(string)
+	maxIdleConnsPerHost := d.Get("max_idle_conns_per_host").(int)
+	idleConnTimeout := d.Get("idle_conn_timeout").(int)
+	compression := d.Get("compression").(bool)
+	dryRun := d.Get("dry_run").(bool)
+	treatMissingAsError := d.Get("treat_missing_as_error").(bool)
+	nameCollisions := d.Get("detect_name_collisions").(bool)
+	metricsFile := d.Get("metrics_file").(string)
+	userAgentSuffix := d.Get("user_agent_suffix").(string)
+	ruleBulkDeleteThreshold := d.Get("rule_bulk_delete_threshold").(int)
+	ruleBulkDeleteWindow := d.Get("rule_bulk_delete_window").(int)
+	scheduleIntervalMin := d.Get("schedule_interval_min").(string)
+	protectedTypes := convertArrayInterfaceToArrayString(d.Get("protected_saved_object_types").(*schema.Set).List())
 
 	// Checks is valid URL
 	if _, err := url.Parse(URL); err != nil {
 		return nil, diag.FromErr(err)
 	}
 
+	// Set can't have default func, so read CA from environment variable when not set
+	if len(cacertFiles) == 0 && os.Getenv("KIBANA_CACERT") != "" {
+		cacertFiles = strings.Split(os.Getenv("KIBANA_CACERT"), ",")
+	}
+
 	// Intialise connexion
 	cfg := kibana.Config{
 		Address: URL,
 		CAs:     cacertFiles,
 	}
-	if username != "" && password != "" {
+	if apiKey == "" && username != "" && password != "" {
 		cfg.Username = username
 		cfg.Password = password
 	}
@@ -123,12 +326,54 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diag.FromErr(err)
 	}
 
+	// Keep connexions open to avoid connexion churn on large apply
+	if err = tuneTransport(client.Client, maxIdleConnsPerHost, time.Duration(idleConnTimeout)*time.Second, compression); err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// Permit to attribute changes on Kibana audit logs
+	if userAgentSuffix != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, userAgentSuffix)
+	}
+	client.Client.SetHeader("User-Agent", userAgent)
+
+	// API key take precedence over basic auth
+	if apiKey != "" {
+		client.Client.UserInfo = nil
+		client.Client.SetHeader("Authorization", fmt.Sprintf("ApiKey %s", apiKey))
+	}
+
+	// Serve recorded API calls instead of contacting Kibana
+	if mockEndpointsFile != "" {
+		transport, err := newMockTransport(mockEndpointsFile)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		client.Client.SetTransport(transport)
+		log.Infof("Mock mode enabled with endpoints file %s", mockEndpointsFile)
+	}
+
+	// Trace API calls when OpenTelemetry endpoint is set
+	if tracingEnabled() {
+		tp, err := getTracerProvider(ctx)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		instrumentClient(client.Client, tp.Tracer(tracerName))
+	}
+
 	logger := log.New()
 	if debug {
 		logger.SetLevel(log.DebugLevel)
 	}
 	logEntry = log.NewEntry(logger)
 
+	// Large bodies, like detection rule params, flood the debug logs
+	if isTraceLogEnabled() {
+		logBodyLimit = 0
+	}
+	setLogBodyLimit(logBodyLimit)
+
 	// Test connexion and check kibana version
 	nbFailed := 0
 	isOnline := false
@@ -151,7 +396,8 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	}
 
 	version := kibanaStatus["version"].(map[string]interface{})["number"].(string)
-	log.Debugf("Server: %s", version)
+	buildFlavor, _ := kibanaStatus["version"].(map[string]interface{})["build_flavor"].(string)
+	log.Debugf("Server: %s (%s)", version, buildFlavor)
 
 	vCurrent := semver.New(version)
 	vMinimal := semver.New("8.0.0")
@@ -160,5 +406,57 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diag.FromErr(errors.New("Kibana is older than 7.0.0"))
 	}
 
-	return client, nil
+	// Pause requests while Kibana is upgrading, instead of failing apply halfway.
+	// It is set after connexion test, that already retry on its own
+	waitKibanaAvailable(client.Client, time.Duration(waitUntilAvailable)*time.Second, time.Duration(waitBeforeRetry)*time.Second)
+
+	meta := &kibanaMeta{
+		client:      client,
+		version:     version,
+		tracker:     newApplyTracker(),
+		dryRun:      dryRun,
+		serverless:  buildFlavor == "serverless",
+		connectors:  newKibanaConnectorCache(),
+		ruleDeletes: newKibanaRuleDeleteBatcher(ruleBulkDeleteThreshold, time.Duration(ruleBulkDeleteWindow)*time.Second),
+	}
+	meta.tracker.metricsFile = metricsFile
+	meta.treatMissingAsError = treatMissingAsError
+	if nameCollisions {
+		meta.names = newKibanaNameRegistry()
+	}
+	if scheduleIntervalMin != "" {
+		if meta.ruleIntervalMin, err = parseKibanaRuleInterval(scheduleIntervalMin); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+	meta.protectedTypes = make(map[string]bool, len(protectedTypes))
+	for _, protectedType := range protectedTypes {
+		meta.protectedTypes[protectedType] = true
+	}
+	if dryRun {
+		log.Infof("Dry run mode enabled, Kibana objects will not be changed")
+	}
+
+	// Get the current user and license. It's not blocking because security or licensing plugin can be disabled
+	user, err := getKibanaCurrentUser(client.Client)
+	if err != nil {
+		log.Warnf("Can't get the current user: %s", err.Error())
+	} else if user != nil {
+		meta.username = user.Username
+		meta.roles = user.Roles
+	}
+	license, err := getKibanaLicense(client.Client)
+	if err != nil {
+		log.Warnf("Can't get the license: %s", err.Error())
+	} else {
+		meta.license = license
+	}
+
+	if meta.serverless {
+		log.Infof("Serverless project detected, rule notify_when and throttle will be set on actions")
+	}
+
+	log.Infof("Connected on Kibana %s as user %s", meta.version, meta.username)
+
+	return meta, nil
 }
@@ -3,10 +3,12 @@ package kb
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/sirupsen/logrus"
 	easy "github.com/t-tomalak/logrus-easy-formatter"
 )
@@ -44,6 +46,50 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProviderEnvironment(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("KIBANA_URL", "http://kibana.mock:5601")
+	t.Setenv("KIBANA_USERNAME", "elastic")
+	t.Setenv("KIBANA_PASSWORD", "changeme")
+	t.Setenv("KIBANA_API_KEY", "dGVzdDp0ZXN0")
+	t.Setenv("KIBANA_SPACE", "terraform-test")
+	t.Setenv("KIBANA_USER_AGENT_SUFFIX", "pipeline/team-a")
+	t.Setenv("KIBANA_MOCK_ENDPOINTS_FILE", path+"/../fixtures/mock-endpoints.json")
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	if diags.HasError() {
+		t.Fatalf("err: %+v", diags)
+	}
+
+	// API key take precedence over basic auth
+	client := provider.Meta().(*kibanaMeta).client.Client
+	if client.UserInfo != nil {
+		t.Errorf("Expected no basic auth, got %+v", client.UserInfo)
+	}
+	if client.Header.Get("Authorization") != "ApiKey dGVzdDp0ZXN0" {
+		t.Errorf("Expected API key header, got %s", client.Header.Get("Authorization"))
+	}
+
+	// User-Agent identify provider and pipeline
+	userAgent := client.Header.Get("User-Agent")
+	if !strings.Contains(userAgent, "terraform-provider-kibana/dev") || !strings.HasSuffix(userAgent, " pipeline/team-a") {
+		t.Errorf("Expected User-Agent with provider and suffix, got %s", userAgent)
+	}
+
+	space, err := defaultSpaceFunc()()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if space != "terraform-test" {
+		t.Errorf("Expected space terraform-test, got %s", space)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 
 	if v := os.Getenv("KIBANA_URL"); v == "" {
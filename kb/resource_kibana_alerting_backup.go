@@ -0,0 +1,213 @@
+// Backup the rules and connectors of space
+// API documentation:
+//   - https://www.elastic.co/guide/en/kibana/current/find-rules-api.html
+//   - https://www.elastic.co/guide/en/kibana/current/get-all-connectors-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// kibanaAlertingBackup is the rules and connectors of space, as written on backup
+type kibanaAlertingBackup struct {
+	Space      string                          `json:"space"`
+	Connectors []kibanaAlertingBackupConnector `json:"connectors"`
+	Rules      []kibanaAlertingRule            `json:"rules"`
+}
+
+// kibanaAlertingBackupConnector is one connector on backup. Kibana never return the secrets
+type kibanaAlertingBackupConnector struct {
+	ID              string         `json:"id"`
+	Name            string         `json:"name"`
+	ConnectorTypeID string         `json:"connector_type_id"`
+	IsPreconfigured bool           `json:"is_preconfigured"`
+	Config          map[string]any `json:"config,omitempty"`
+}
+
+// Resource specification to backup the rules and connectors of space
+func resourceKibanaAlertingBackup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaAlertingBackupCreate,
+		ReadContext:   resourceKibanaAlertingBackupRead,
+		UpdateContext: resourceKibanaAlertingBackupUpdate,
+		DeleteContext: resourceKibanaAlertingBackupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"backup_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rules_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"connectors_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Backup rules and connectors
+func resourceKibanaAlertingBackupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+
+	if diags := backupKibanaAlerting(d, meta); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(space)
+
+	log.Infof("Backup alerting of space %s successfully", space)
+	fmt.Printf("[INFO] Backup alerting of space %s successfully", space)
+
+	return resourceKibanaAlertingBackupRead(ctx, d, meta)
+}
+
+// Read backup
+// The backup is not saved on Kibana, so it keep the state as is
+func resourceKibanaAlertingBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	if err := d.Set("space", id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read alerting backup %s successfully", id)
+	fmt.Printf("[INFO] Read alerting backup %s successfully", id)
+
+	return nil
+}
+
+// Backup again rules and connectors
+func resourceKibanaAlertingBackupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	if diags := backupKibanaAlerting(d, meta); diags.HasError() {
+		return diags
+	}
+
+	log.Infof("Backup again alerting of space %s successfully", id)
+	fmt.Printf("[INFO] Backup again alerting of space %s successfully", id)
+
+	return resourceKibanaAlertingBackupRead(ctx, d, meta)
+}
+
+// Delete backup just remove resource from state, the file is kept
+func resourceKibanaAlertingBackupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	d.SetId("")
+
+	log.Infof("Delete alerting backup - just removing from state")
+	fmt.Printf("[INFO] Delete alerting backup - just removing from state")
+	return nil
+}
+
+// backupKibanaAlerting permit to list rules and connectors, and write them on state and file
+func backupKibanaAlerting(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+	file := d.Get("file").(string)
+
+	client := meta.(*kibanaMeta).client
+
+	rules, err := listAllPages(defaultPerPage, func(page int, perPage int) ([]kibanaAlertingRule, int, error) {
+		data, err := findKibanaAlertingRules(client.Client, space, "", page, perPage)
+		if err != nil {
+			return nil, 0, err
+		}
+		return data.Data, data.Total, nil
+	})
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("find rules of space %s", space))
+	}
+	connectors, err := listKibanaConnectors(client.Client, space)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("list connectors of space %s", space))
+	}
+
+	backup := newKibanaAlertingBackup(space, rules, connectors)
+	backupJSON, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if file != "" {
+		if err = os.WriteFile(file, backupJSON, 0600); err != nil {
+			return diag.Errorf("Error when write alerting backup on %s: %s", file, err.Error())
+		}
+		log.Debugf("Write alerting backup on %s", file)
+	}
+
+	if err = d.Set("backup_json", string(backupJSON)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("rules_count", len(backup.Rules)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("connectors_count", len(backup.Connectors)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// newKibanaAlertingBackup permit to build stable backup, without execution status and sorted by ID
+func newKibanaAlertingBackup(space string, rules []kibanaAlertingRule, connectors []kibanaConnector) *kibanaAlertingBackup {
+	backup := &kibanaAlertingBackup{
+		Space:      space,
+		Connectors: make([]kibanaAlertingBackupConnector, 0, len(connectors)),
+		Rules:      make([]kibanaAlertingRule, 0, len(rules)),
+	}
+
+	for _, connector := range connectors {
+		backup.Connectors = append(backup.Connectors, kibanaAlertingBackupConnector{
+			ID:              connector.ID,
+			Name:            connector.Name,
+			ConnectorTypeID: connector.ConnectorTypeID,
+			IsPreconfigured: connector.IsPreconfigured,
+			Config:          connector.Config,
+		})
+	}
+	for _, rule := range rules {
+		rule.ExecutionStatus = nil
+		backup.Rules = append(backup.Rules, rule)
+	}
+
+	sort.Slice(backup.Connectors, func(i, j int) bool {
+		return backup.Connectors[i].ID < backup.Connectors[j].ID
+	})
+	sort.Slice(backup.Rules, func(i, j int) bool {
+		return backup.Rules[i].ID < backup.Rules[j].ID
+	})
+
+	return backup
+}
@@ -0,0 +1,77 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKibanaAlertingBackup(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaAlertingBackup,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_alerting_backup.test", "rules_count", "1"),
+					resource.TestCheckResourceAttrSet("kibana_alerting_backup.test", "backup_json"),
+				),
+			},
+		},
+	})
+}
+
+func TestNewKibanaAlertingBackup(t *testing.T) {
+	rules := []kibanaAlertingRule{
+		{ID: "rule-2", Name: "B", ExecutionStatus: &kibanaAlertingRuleExecStatus{Status: "ok"}},
+		{ID: "rule-1", Name: "A", ExecutionStatus: &kibanaAlertingRuleExecStatus{Status: "active"}},
+	}
+	connectors := []kibanaConnector{
+		{ID: "slack", Name: "Slack", ConnectorTypeID: ".slack", ReferencedByCount: 2},
+		{ID: "email", Name: "Email", ConnectorTypeID: ".email", IsPreconfigured: true, Config: map[string]any{"from": "kibana@company.com"}},
+	}
+
+	backup := newKibanaAlertingBackup("team-a", rules, connectors)
+	if backup.Space != "team-a" {
+		t.Errorf("Expected space team-a, got %s", backup.Space)
+	}
+	if len(backup.Rules) != 2 || backup.Rules[0].ID != "rule-1" || backup.Rules[1].ID != "rule-2" {
+		t.Errorf("Expected rules sorted by ID, got %+v", backup.Rules)
+	}
+	for _, rule := range backup.Rules {
+		if rule.ExecutionStatus != nil {
+			t.Errorf("Expected no execution status on rule %s", rule.ID)
+		}
+	}
+	if len(backup.Connectors) != 2 || backup.Connectors[0].ID != "email" || !backup.Connectors[0].IsPreconfigured || backup.Connectors[0].Config["from"] != "kibana@company.com" {
+		t.Errorf("Unexpected connectors: %+v", backup.Connectors)
+	}
+}
+
+var testKibanaAlertingBackup = `
+resource kibana_user_space "test" {
+  uid  = "terraform-test-backup"
+  name = "terraform-test-backup"
+}
+
+resource kibana_index_threshold_rule "test" {
+  space                = kibana_user_space.test.uid
+  name                 = "terraform-test"
+  index                = ["terraform-test"]
+  time_field           = "@timestamp"
+  threshold_comparator = ">"
+  threshold            = [100]
+}
+
+resource kibana_alerting_backup "test" {
+  space = kibana_user_space.test.uid
+
+  triggers = {
+    rule = kibana_index_threshold_rule.test.rule_id
+  }
+}
+`
@@ -0,0 +1,266 @@
+// Restore the rules and connectors of space from backup
+// API documentation:
+//   - https://www.elastic.co/guide/en/kibana/current/create-rule-api.html
+//   - https://www.elastic.co/guide/en/kibana/current/create-connector-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+// kibanaAlertingRestorePlan is the connectors and rules to create, because they not exist on Kibana
+type kibanaAlertingRestorePlan struct {
+	Connectors        []kibanaAlertingBackupConnector
+	Rules             []kibanaAlertingRule
+	ConnectorsSkipped int
+	RulesSkipped      int
+}
+
+// Resource specification to restore the rules and connectors of space from backup
+func resourceKibanaAlertingRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaAlertingRestoreCreate,
+		ReadContext:   resourceKibanaAlertingRestoreRead,
+		UpdateContext: resourceKibanaAlertingRestoreUpdate,
+		DeleteContext: resourceKibanaAlertingRestoreDelete,
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"backup_json": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"connector_secrets": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"connectors_restored": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"connectors_skipped": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rules_restored": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rules_skipped": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Restore rules and connectors
+func resourceKibanaAlertingRestoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+
+	if diags := restoreKibanaAlerting(d, meta); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(space)
+
+	log.Infof("Restore alerting of space %s successfully", space)
+	fmt.Printf("[INFO] Restore alerting of space %s successfully", space)
+
+	return resourceKibanaAlertingRestoreRead(ctx, d, meta)
+}
+
+// Read restore
+// The restore is not saved on Kibana, so it keep the state as is
+func resourceKibanaAlertingRestoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	if err := d.Set("space", id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read alerting restore %s successfully", id)
+	fmt.Printf("[INFO] Read alerting restore %s successfully", id)
+
+	return nil
+}
+
+// Restore again the rules and connectors that not exist
+func resourceKibanaAlertingRestoreUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	if diags := restoreKibanaAlerting(d, meta); diags.HasError() {
+		return diags
+	}
+
+	log.Infof("Restore again alerting of space %s successfully", id)
+	fmt.Printf("[INFO] Restore again alerting of space %s successfully", id)
+
+	return resourceKibanaAlertingRestoreRead(ctx, d, meta)
+}
+
+// Delete restore just remove resource from state, the restored rules and connectors are kept
+func resourceKibanaAlertingRestoreDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	d.SetId("")
+
+	log.Infof("Delete alerting restore - just removing from state")
+	fmt.Printf("[INFO] Delete alerting restore - just removing from state")
+	return nil
+}
+
+// restoreKibanaAlerting permit to create the connectors, then the rules, of backup that not exist on Kibana
+func restoreKibanaAlerting(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+
+	backup := &kibanaAlertingBackup{}
+	if err := json.Unmarshal([]byte(d.Get("backup_json").(string)), backup); err != nil {
+		return diag.Errorf("Error when read alerting backup: %s", err.Error())
+	}
+	connectorSecrets := map[string]map[string]any{}
+	for id, rawSecrets := range d.Get("connector_secrets").(map[string]interface{}) {
+		secrets := map[string]any{}
+		if err := json.Unmarshal([]byte(rawSecrets.(string)), &secrets); err != nil {
+			return diag.Errorf("Error when read secrets of connector %s: %s", id, err.Error())
+		}
+		connectorSecrets[id] = secrets
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	rules, err := listAllPages(defaultPerPage, func(page int, perPage int) ([]kibanaAlertingRule, int, error) {
+		data, err := findKibanaAlertingRules(client.Client, space, "", page, perPage)
+		if err != nil {
+			return nil, 0, err
+		}
+		return data.Data, data.Total, nil
+	})
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("find rules of space %s", space))
+	}
+	connectors, err := listKibanaConnectors(client.Client, space)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("list connectors of space %s", space))
+	}
+
+	plan := planKibanaAlertingRestore(backup, connectors, rules)
+	log.Debugf("Restore plan: %+v", plan)
+
+	// Rules need their connectors, so stop before creating rules when connectors failed
+	errs := make([]string, 0)
+	for _, connector := range plan.Connectors {
+		secrets, ok := connectorSecrets[connector.ID]
+		if !ok {
+			secrets = map[string]any{}
+		}
+		if err = createKibanaConnector(client.Client, space, connector.ID, &kibanaConnectorCreation{
+			Name:            connector.Name,
+			ConnectorTypeID: connector.ConnectorTypeID,
+			Config:          connector.Config,
+			Secrets:         secrets,
+		}); err != nil {
+			errs = append(errs, fmt.Sprintf("Connector %s: %s", connector.ID, err.Error()))
+		}
+	}
+	if len(errs) == 0 {
+		for i := range plan.Rules {
+			if _, err = createKibanaAlertingRule(client.Client, space, &plan.Rules[i]); err != nil {
+				errs = append(errs, fmt.Sprintf("Rule %s: %s", plan.Rules[i].ID, err.Error()))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to restore alerting of space %s", space),
+			Detail:   strings.Join(errs, "\n"),
+		}}
+	}
+
+	if err = d.Set("connectors_restored", len(plan.Connectors)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("connectors_skipped", plan.ConnectorsSkipped); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("rules_restored", len(plan.Rules)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("rules_skipped", plan.RulesSkipped); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// planKibanaAlertingRestore return the connectors and rules of backup that not exist on Kibana.
+// The preconfigured connectors are never restored, they are defined on kibana.yml
+func planKibanaAlertingRestore(backup *kibanaAlertingBackup, connectors []kibanaConnector, rules []kibanaAlertingRule) *kibanaAlertingRestorePlan {
+	plan := &kibanaAlertingRestorePlan{
+		Connectors: make([]kibanaAlertingBackupConnector, 0),
+		Rules:      make([]kibanaAlertingRule, 0),
+	}
+
+	existingConnectors := make(map[string]bool, len(connectors))
+	for _, connector := range connectors {
+		existingConnectors[connector.ID] = true
+	}
+	existingRules := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		existingRules[rule.ID] = true
+	}
+
+	for _, connector := range backup.Connectors {
+		if connector.IsPreconfigured || existingConnectors[connector.ID] {
+			plan.ConnectorsSkipped++
+			continue
+		}
+		plan.Connectors = append(plan.Connectors, connector)
+	}
+	for _, rule := range backup.Rules {
+		if existingRules[rule.ID] {
+			plan.RulesSkipped++
+			continue
+		}
+		// Kibana reject notify_when and throttle on rule when actions have frequency
+		for _, action := range rule.Actions {
+			if action.Frequency != nil {
+				rule.NotifyWhen = ""
+				rule.Throttle = nil
+				break
+			}
+		}
+		rule.ExecutionStatus = nil
+		plan.Rules = append(plan.Rules, rule)
+	}
+
+	return plan
+}
@@ -0,0 +1,99 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKibanaAlertingRestore(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaAlertingRestore,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_alerting_restore.test", "rules_restored", "1"),
+					resource.TestCheckResourceAttr("kibana_alerting_restore.test", "rules_skipped", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestPlanKibanaAlertingRestore(t *testing.T) {
+	throttle := "1h"
+	backup := &kibanaAlertingBackup{
+		Space: "default",
+		Connectors: []kibanaAlertingBackupConnector{
+			{ID: "slack", ConnectorTypeID: ".slack"},
+			{ID: "email", ConnectorTypeID: ".email", IsPreconfigured: true},
+			{ID: "webhook", ConnectorTypeID: ".webhook"},
+		},
+		Rules: []kibanaAlertingRule{
+			{ID: "rule-1", NotifyWhen: "onThrottleInterval", Throttle: &throttle},
+			{
+				ID:         "rule-2",
+				NotifyWhen: "onActiveAlert",
+				Actions: []kibanaAlertingRuleAction{
+					{ID: "slack", Group: "threshold met", Frequency: &kibanaAlertingRuleActionFrequency{NotifyWhen: "onActiveAlert"}},
+				},
+			},
+			{ID: "rule-3"},
+		},
+	}
+	connectors := []kibanaConnector{{ID: "webhook"}}
+	rules := []kibanaAlertingRule{{ID: "rule-3"}}
+
+	plan := planKibanaAlertingRestore(backup, connectors, rules)
+	if len(plan.Connectors) != 1 || plan.Connectors[0].ID != "slack" || plan.ConnectorsSkipped != 2 {
+		t.Errorf("Expected to restore only slack connector, got %+v", plan)
+	}
+	if len(plan.Rules) != 2 || plan.Rules[0].ID != "rule-1" || plan.Rules[1].ID != "rule-2" || plan.RulesSkipped != 1 {
+		t.Errorf("Expected to restore rule-1 and rule-2, got %+v", plan)
+	}
+	if plan.Rules[0].NotifyWhen != "onThrottleInterval" || plan.Rules[0].Throttle == nil {
+		t.Errorf("Expected notify_when kept on rule without action frequency, got %+v", plan.Rules[0])
+	}
+	if plan.Rules[1].NotifyWhen != "" || plan.Rules[1].Throttle != nil {
+		t.Errorf("Expected no notify_when on rule with action frequency, got %+v", plan.Rules[1])
+	}
+}
+
+var testKibanaAlertingRestore = `
+resource kibana_user_space "source" {
+  uid  = "terraform-test-restore-source"
+  name = "terraform-test-restore-source"
+}
+
+resource kibana_user_space "target" {
+  uid  = "terraform-test-restore-target"
+  name = "terraform-test-restore-target"
+}
+
+resource kibana_index_threshold_rule "test" {
+  space                = kibana_user_space.source.uid
+  name                 = "terraform-test"
+  index                = ["terraform-test"]
+  time_field           = "@timestamp"
+  threshold_comparator = ">"
+  threshold            = [100]
+}
+
+resource kibana_alerting_backup "test" {
+  space = kibana_user_space.source.uid
+
+  triggers = {
+    rule = kibana_index_threshold_rule.test.rule_id
+  }
+}
+
+resource kibana_alerting_restore "test" {
+  space       = kibana_user_space.target.uid
+  backup_json = kibana_alerting_backup.test.backup_json
+}
+`
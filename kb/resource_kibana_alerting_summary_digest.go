@@ -0,0 +1,491 @@
+// Add the same summary action on many rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/master/alerting-apis.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource specification to add summary action on many rules in Kibana
+func resourceKibanaAlertingSummaryDigest() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaAlertingSummaryDigestCreate,
+		ReadContext:   resourceKibanaAlertingSummaryDigestRead,
+		UpdateContext: resourceKibanaAlertingSummaryDigestUpdate,
+		DeleteContext: resourceKibanaAlertingSummaryDigestDelete,
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if d.Id() == "" {
+				return nil
+			}
+			// Rules that lost or gained the summary action since last apply are reconciled on apply
+			if d.Get("rules_drifted").(int) > 0 {
+				if err := d.SetNew("rules_drifted", 0); err != nil {
+					return err
+				}
+				return d.SetNewComputed("rule_ids")
+			}
+			if d.HasChanges("tags", "filter", "triggers") {
+				return d.SetNewComputed("rule_ids")
+			}
+			return nil
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"tags": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{"tags", "filter"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"filter": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"tags", "filter"},
+			},
+			"connector_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"group": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"params": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+			},
+			"notify_when": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "onThrottleInterval",
+				ValidateFunc: validation.StringInSlice([]string{"onActiveAlert", "onThrottleInterval"}, false),
+			},
+			"throttle": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "1d",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[1-9][0-9]*[smhd]$`), "must be a duration like 1d"),
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rules_succeeded": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rules_skipped": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rules_drifted": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rule_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// Add summary action on rules
+func resourceKibanaAlertingSummaryDigestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+
+	action, err := buildKibanaAlertingSummaryDigestAction(d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	diags := applyKibanaAlertingSummaryDigest(d, meta, action, nil, true)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(name)
+
+	log.Infof("Applied summary digest %s successfully", name)
+
+	return append(diags, resourceKibanaAlertingSummaryDigestRead(ctx, d, meta)...)
+}
+
+// Read summary digest
+// The summary action is set on each rule, so it count the rules that lost or gained it since last apply
+func resourceKibanaAlertingSummaryDigestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	action, err := buildKibanaAlertingSummaryDigestAction(d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, matchingIDs, err := findKibanaAlertingSummaryDigestRules(d, meta)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("find rules of summary digest %s", id))
+	}
+	plan := planKibanaAlertingSummaryDigest(rules, matchingIDs, managedKibanaAlertingSummaryDigestRules(d), action, nil)
+
+	if err = d.Set("name", id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("rules_drifted", len(plan.add)+len(plan.remove)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read summary digest %s successfully", id)
+
+	return nil
+}
+
+// Update summary digest. The summary action of previous apply is removed from rules when it's changed
+func resourceKibanaAlertingSummaryDigestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	action, err := buildKibanaAlertingSummaryDigestAction(d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldAction, err := buildKibanaAlertingSummaryDigestAction(func(key string) interface{} {
+		old, _ := d.GetChange(key)
+		return old
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	staleActions := make([]*kibanaAlertingRuleAction, 0, 1)
+	if !isSameKibanaAlertingSummaryAction(oldAction, action) || !isSameKibanaAlertingSummaryAction(action, oldAction) {
+		staleActions = append(staleActions, oldAction)
+	}
+
+	diags := applyKibanaAlertingSummaryDigest(d, meta, action, staleActions, true)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Infof("Updated summary digest %s successfully", id)
+
+	return append(diags, resourceKibanaAlertingSummaryDigestRead(ctx, d, meta)...)
+}
+
+// Delete summary digest remove the summary action from all rules
+func resourceKibanaAlertingSummaryDigestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	action, err := buildKibanaAlertingSummaryDigestAction(d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := applyKibanaAlertingSummaryDigest(d, meta, action, []*kibanaAlertingRuleAction{action}, false); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+
+	log.Infof("Deleted summary digest %s successfully", id)
+	return nil
+}
+
+// findKibanaAlertingSummaryDigestRules return all rules of space, and the ID of them that match the tags and the filter
+func findKibanaAlertingSummaryDigestRules(d *schema.ResourceData, meta interface{}) ([]kibanaAlertingRule, map[string]bool, error) {
+	space := d.Get("space").(string)
+	filter := buildKibanaAlertingSummaryDigestFilter(convertArrayInterfaceToArrayString(d.Get("tags").(*schema.Set).List()), d.Get("filter").(string))
+
+	client := meta.(*kibanaMeta).client
+
+	findRules := func(filter string) ([]kibanaAlertingRule, error) {
+		return listAllPages(defaultPerPage, func(page int, perPage int) ([]kibanaAlertingRule, int, error) {
+			data, err := findKibanaAlertingRules(client.Client, space, filter, page, perPage)
+			if err != nil {
+				return nil, 0, err
+			}
+			return data.Data, data.Total, nil
+		})
+	}
+
+	// All rules are needed to find them that not match anymore but still have the summary action
+	rules, err := findRules("")
+	if err != nil {
+		return nil, nil, err
+	}
+	matchingRules, err := findRules(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	matchingIDs := make(map[string]bool, len(matchingRules))
+	for _, rule := range matchingRules {
+		matchingIDs[rule.ID] = true
+	}
+
+	return rules, matchingIDs, nil
+}
+
+// applyKibanaAlertingSummaryDigest permit to remove the stale summary actions from rules, then to add the summary action, with bulk edit,
+// on the rules that match and not have it yet. When add is false, the summary action is only removed
+func applyKibanaAlertingSummaryDigest(d *schema.ResourceData, meta interface{}, action *kibanaAlertingRuleAction, staleActions []*kibanaAlertingRuleAction, add bool) diag.Diagnostics {
+	name := d.Get("name").(string)
+	space := d.Get("space").(string)
+	log.Debugf("Summary action: %+v", action)
+
+	client := meta.(*kibanaMeta).client
+
+	rules, matchingIDs, err := findKibanaAlertingSummaryDigestRules(d, meta)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("find rules of summary digest %s", name))
+	}
+	if !add {
+		matchingIDs = map[string]bool{}
+	}
+	plan := planKibanaAlertingSummaryDigest(rules, matchingIDs, managedKibanaAlertingSummaryDigestRules(d), action, staleActions)
+
+	// Bulk edit can't remove one action, so rules are updated one by one
+	errs := make([]string, 0)
+	for _, removal := range plan.remove {
+		log.Debugf("Remove summary action from rule %s", removal.ruleID)
+		if _, err = removeKibanaAlertingRuleActions(client.Client, space, removal.ruleID, removal.match); err != nil {
+			errs = append(errs, fmt.Sprintf("Rule %s: %s", removal.ruleID, err.Error()))
+		}
+	}
+
+	if len(plan.add) > 0 && len(errs) == 0 {
+		_, ruleErrs, err := bulkEditKibanaAlertingRules(client.Client, space, plan.add, []kibanaAlertingRulesBulkEditOperation{{
+			Operation: "add",
+			Field:     "actions",
+			Value:     []kibanaAlertingRuleAction{*action},
+		}})
+		if err != nil {
+			return handleAPIError(err, fmt.Sprintf("apply summary digest %s", name))
+		}
+		for id, ruleErr := range ruleErrs {
+			errs = append(errs, fmt.Sprintf("Rule %s: %s", id, ruleErr.Error()))
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to apply summary digest %s on %d rules", name, len(errs)),
+			Detail:   strings.Join(errs, "\n"),
+		}}
+	}
+
+	// Rule can have the previous summary action removed and the new one added
+	editedIDs := make(map[string]bool, len(plan.add)+len(plan.remove))
+	for _, id := range plan.add {
+		editedIDs[id] = true
+	}
+	for _, removal := range plan.remove {
+		editedIDs[removal.ruleID] = true
+	}
+	if err = d.Set("rules_succeeded", len(editedIDs)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("rules_skipped", plan.skipped+len(plan.incompatible)); err != nil {
+		return diag.FromErr(err)
+	}
+	incompatibleIDs := make(map[string]bool, len(plan.incompatible))
+	for _, id := range plan.incompatible {
+		incompatibleIDs[id] = true
+	}
+	ruleIDs := make([]string, 0, len(matchingIDs))
+	for id := range matchingIDs {
+		if !incompatibleIDs[id] {
+			ruleIDs = append(ruleIDs, id)
+		}
+	}
+	if err = d.Set("rule_ids", ruleIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(plan.incompatible) > 0 {
+		log.Warnf("Summary digest %s skip rules with notify_when or throttle of rule: %s", name, strings.Join(plan.incompatible, ", "))
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Summary digest %s not applied on %d rules", name, len(plan.incompatible)),
+			Detail: fmt.Sprintf("Rules %s set notify_when or throttle at rule level, so Kibana not allow the frequency of the summary action on them. "+
+				"The typed rule resources of this provider always set them, except on serverless. Move the frequency to their actions, or exclude them with tags or filter.", strings.Join(plan.incompatible, ", ")),
+		}}
+	}
+
+	return nil
+}
+
+// managedKibanaAlertingSummaryDigestRules return the ID of rules that got the summary action on last apply.
+// The summary action is only removed from them, to not remove the same action added by someone else
+func managedKibanaAlertingSummaryDigestRules(d *schema.ResourceData) map[string]bool {
+	managedIDs := map[string]bool{}
+	for _, id := range convertArrayInterfaceToArrayString(d.Get("rule_ids").(*schema.Set).List()) {
+		managedIDs[id] = true
+	}
+
+	return managedIDs
+}
+
+// buildKibanaAlertingSummaryDigestFilter permit to build the KQL filter of rules. The rules must have one of tags, and match the filter
+func buildKibanaAlertingSummaryDigestFilter(tags []string, filter string) string {
+	filters := make([]string, 0, 2)
+	if len(tags) > 0 {
+		sort.Strings(tags)
+		quotedTags := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			quotedTags = append(quotedTags, fmt.Sprintf("%q", tag))
+		}
+		filters = append(filters, fmt.Sprintf("alert.attributes.tags:(%s)", strings.Join(quotedTags, " or ")))
+	}
+	if filter = strings.TrimSpace(filter); filter != "" {
+		filters = append(filters, fmt.Sprintf("(%s)", filter))
+	}
+
+	return strings.Join(filters, " and ")
+}
+
+// buildKibanaAlertingSummaryDigestAction permit to build the summary action from the attribute values, current or previous ones
+func buildKibanaAlertingSummaryDigestAction(get func(key string) interface{}) (*kibanaAlertingRuleAction, error) {
+	params := map[string]any{}
+	if err := json.Unmarshal([]byte(get("params").(string)), &params); err != nil {
+		return nil, fmt.Errorf("Error when decode params: %s", err.Error())
+	}
+	action := &kibanaAlertingRuleAction{
+		ID:     get("connector_id").(string),
+		Group:  get("group").(string),
+		Params: params,
+		Frequency: &kibanaAlertingRuleActionFrequency{
+			Summary:    true,
+			NotifyWhen: get("notify_when").(string),
+		},
+	}
+	// Throttle is only allowed when action run on interval
+	if action.Frequency.NotifyWhen == "onThrottleInterval" {
+		throttle := get("throttle").(string)
+		action.Frequency.Throttle = &throttle
+	}
+
+	return action, nil
+}
+
+// kibanaAlertingSummaryDigestPlan is the rules to edit to reconcile the summary action
+type kibanaAlertingSummaryDigestPlan struct {
+	add          []string                             // ID of rules that need the summary action
+	remove       []kibanaAlertingSummaryDigestRemoval // Rules where summary actions must be removed
+	skipped      int                                  // Number of rules already up to date
+	incompatible []string                             // ID of matching rules with notify_when or throttle of rule, where the summary action can't be added
+}
+
+// kibanaAlertingSummaryDigestRemoval is the summary actions to remove from one rule
+type kibanaAlertingSummaryDigestRemoval struct {
+	ruleID  string
+	actions []*kibanaAlertingRuleAction
+}
+
+// match return true when the rule action is one of the summary actions to remove
+func (r kibanaAlertingSummaryDigestRemoval) match(ruleAction *kibanaAlertingRuleAction) bool {
+	for _, action := range r.actions {
+		if isSameKibanaAlertingSummaryAction(ruleAction, action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// planKibanaAlertingSummaryDigest return the rules to edit. On managed rules, the stale actions, and the summary action when rule not match anymore, are removed.
+// The summary action is added on matching rules that not have it, because bulk edit always append the action, so it must not be added twice.
+// Kibana reject the action frequency on rules with notify_when or throttle, so they are reported as incompatible
+func planKibanaAlertingSummaryDigest(rules []kibanaAlertingRule, matchingIDs map[string]bool, managedIDs map[string]bool, action *kibanaAlertingRuleAction, staleActions []*kibanaAlertingRuleAction) *kibanaAlertingSummaryDigestPlan {
+	plan := &kibanaAlertingSummaryDigestPlan{
+		add:          make([]string, 0),
+		remove:       make([]kibanaAlertingSummaryDigestRemoval, 0),
+		incompatible: make([]string, 0),
+	}
+	for _, rule := range rules {
+		matching := matchingIDs[rule.ID]
+		removal := kibanaAlertingSummaryDigestRemoval{ruleID: rule.ID}
+		if managedIDs[rule.ID] {
+			removal.actions = append(removal.actions, staleActions...)
+			if !matching {
+				removal.actions = append(removal.actions, action)
+			}
+		}
+
+		found := false
+		removed := false
+		for i := range rule.Actions {
+			if removal.match(&rule.Actions[i]) {
+				removed = true
+				continue
+			}
+			if isSameKibanaAlertingSummaryAction(&rule.Actions[i], action) {
+				found = true
+			}
+		}
+
+		if removed {
+			plan.remove = append(plan.remove, removal)
+		}
+		switch {
+		case matching && !found && (rule.NotifyWhen != "" || rule.Throttle != nil):
+			plan.incompatible = append(plan.incompatible, rule.ID)
+		case matching && !found:
+			plan.add = append(plan.add, rule.ID)
+		case matching && !removed:
+			plan.skipped++
+		}
+	}
+
+	return plan
+}
+
+// isSameKibanaAlertingSummaryAction permit to check the rule action is the expected summary action
+func isSameKibanaAlertingSummaryAction(ruleAction *kibanaAlertingRuleAction, action *kibanaAlertingRuleAction) bool {
+	if ruleAction.ID != action.ID || ruleAction.Frequency == nil || !ruleAction.Frequency.Summary {
+		return false
+	}
+	if action.Group != "" && ruleAction.Group != action.Group {
+		return false
+	}
+	if ruleAction.Frequency.NotifyWhen != action.Frequency.NotifyWhen {
+		return false
+	}
+	if action.Frequency.NotifyWhen == "onThrottleInterval" && (ruleAction.Frequency.Throttle == nil || *ruleAction.Frequency.Throttle != *action.Frequency.Throttle) {
+		return false
+	}
+
+	return reflect.DeepEqual(ruleAction.Params, action.Params)
+}
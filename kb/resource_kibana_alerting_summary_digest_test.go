@@ -0,0 +1,365 @@
+package kb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKibanaAlertingSummaryDigest(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testCreateKibanaAlertingSummaryDigestRule(t)
+				},
+				Config: testKibanaAlertingSummaryDigest,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_alerting_summary_digest.test", "rules_succeeded", "1"),
+					// Typed rules set notify_when of rule, so they are skipped
+					resource.TestCheckResourceAttr("kibana_alerting_summary_digest.test", "rules_skipped", "1"),
+					testCheckKibanaAlertingSummaryDigestActions("terraform-test-digest", 1),
+				),
+			},
+			{
+				// Rules that already have the summary action are skipped
+				Config: testKibanaAlertingSummaryDigestTriggered,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_alerting_summary_digest.test", "rules_succeeded", "0"),
+					resource.TestCheckResourceAttr("kibana_alerting_summary_digest.test", "rules_skipped", "2"),
+				),
+			},
+			{
+				// The previous summary action is replaced, not kept next to the new one
+				Config: strings.Replace(testKibanaAlertingSummaryDigestTriggered, "new alerts", "new alerts today", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_alerting_summary_digest.test", "rules_succeeded", "1"),
+					testCheckKibanaAlertingSummaryDigestActions("terraform-test-digest", 1),
+				),
+			},
+		},
+	})
+}
+
+// testCreateKibanaAlertingSummaryDigestRule permit to create the connector, and the rule like created from Kibana UI, without notify_when of rule
+func testCreateKibanaAlertingSummaryDigestRule(t *testing.T) {
+	client := testAccProvider.Meta().(*kibanaMeta).client
+	connectors, err := listKibanaConnectors(client.Client, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, connector := range connectors {
+		found = found || connector.ID == "terraform-test-digest"
+	}
+	if !found {
+		if err = createKibanaConnector(client.Client, "default", "terraform-test-digest", &kibanaConnectorCreation{
+			Name:            "terraform-test-digest",
+			ConnectorTypeID: ".server-log",
+			Config:          map[string]any{},
+			Secrets:         map[string]any{},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rule, err := getKibanaAlertingRule(client.Client, "default", "terraform-test-digest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule != nil {
+		return
+	}
+	if _, err = createKibanaAlertingRule(client.Client, "default", &kibanaAlertingRule{
+		ID:         "terraform-test-digest",
+		Name:       "terraform-test-digest",
+		RuleTypeID: ".es-query",
+		Consumer:   "stackAlerts",
+		Tags:       []string{"terraform-test-digest"},
+		Schedule:   kibanaAlertingRuleSchedule{Interval: "1m"},
+		Params: map[string]any{
+			"searchType":          "esQuery",
+			"index":               []string{"terraform-test"},
+			"timeField":           "@timestamp",
+			"esQuery":             `{"query":{"match_all":{}}}`,
+			"size":                100,
+			"threshold":           []int{0},
+			"thresholdComparator": ">",
+			"timeWindowSize":      5,
+			"timeWindowUnit":      "m",
+		},
+		Actions: []kibanaAlertingRuleAction{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := deleteKibanaAlertingRule(client.Client, "default", "terraform-test-digest"); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func testCheckKibanaAlertingSummaryDigestActions(ruleID string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*kibanaMeta).client
+		rule, err := getKibanaAlertingRule(client.Client, "default", ruleID)
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			return fmt.Errorf("Rule %s not found", ruleID)
+		}
+		count := 0
+		for _, action := range rule.Actions {
+			if action.Frequency != nil && action.Frequency.Summary {
+				count++
+			}
+		}
+		if count != expected {
+			return fmt.Errorf("Expected %d summary actions on rule %s, got %d", expected, ruleID, count)
+		}
+
+		return nil
+	}
+}
+
+func TestBuildKibanaAlertingSummaryDigestFilter(t *testing.T) {
+	if filter := buildKibanaAlertingSummaryDigestFilter([]string{"team-b", "team-a"}, ""); filter != `alert.attributes.tags:("team-a" or "team-b")` {
+		t.Errorf("Unexpected filter with tags: %s", filter)
+	}
+	if filter := buildKibanaAlertingSummaryDigestFilter(nil, `alert.attributes.enabled:true`); filter != `(alert.attributes.enabled:true)` {
+		t.Errorf("Unexpected filter with KQL: %s", filter)
+	}
+	if filter := buildKibanaAlertingSummaryDigestFilter([]string{"prod"}, `alert.attributes.alertTypeId:".es-query"`); filter != `alert.attributes.tags:("prod") and (alert.attributes.alertTypeId:".es-query")` {
+		t.Errorf("Unexpected filter with tags and KQL: %s", filter)
+	}
+}
+
+func TestPlanKibanaAlertingSummaryDigest(t *testing.T) {
+	r := resourceKibanaAlertingSummaryDigest()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]any{
+		"name":         "test",
+		"tags":         []any{"prod"},
+		"connector_id": "slack",
+		"params":       `{"message": "{{alerts.new.count}} new alerts"}`,
+	})
+	action, err := buildKibanaAlertingSummaryDigestAction(d.Get)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !action.Frequency.Summary || action.Frequency.NotifyWhen != "onThrottleInterval" || action.Frequency.Throttle == nil || *action.Frequency.Throttle != "1d" {
+		t.Errorf("Unexpected frequency: %+v", action.Frequency)
+	}
+
+	throttle := "1d"
+	otherThrottle := "1h"
+	summaryAction := func(throttle *string) kibanaAlertingRuleAction {
+		return kibanaAlertingRuleAction{
+			ID:        "slack",
+			Params:    map[string]any{"message": "{{alerts.new.count}} new alerts"},
+			Frequency: &kibanaAlertingRuleActionFrequency{Summary: true, NotifyWhen: "onThrottleInterval", Throttle: throttle},
+		}
+	}
+	alertAction := kibanaAlertingRuleAction{
+		ID:        "slack",
+		Params:    map[string]any{"message": "{{alerts.new.count}} new alerts"},
+		Frequency: &kibanaAlertingRuleActionFrequency{Summary: false, NotifyWhen: "onActiveAlert"},
+	}
+	rules := []kibanaAlertingRule{
+		// Already has the summary action
+		{ID: "rule-1", Actions: []kibanaAlertingRuleAction{summaryAction(&throttle)}},
+		// Same connector, but for each alert
+		{ID: "rule-2", Actions: []kibanaAlertingRuleAction{alertAction}},
+		// Summary action with another frequency
+		{ID: "rule-3", Actions: []kibanaAlertingRuleAction{summaryAction(&otherThrottle)}},
+		// Without action
+		{ID: "rule-4"},
+		// Not matching anymore
+		{ID: "rule-5", Actions: []kibanaAlertingRuleAction{alertAction, summaryAction(&throttle)}},
+		// Not matching and not managed, the same action is added by someone else
+		{ID: "rule-6", Actions: []kibanaAlertingRuleAction{summaryAction(&throttle)}},
+		// Notify when of rule, like typed rules, not allow action frequency
+		{ID: "rule-7", NotifyWhen: "onActiveAlert", Actions: []kibanaAlertingRuleAction{{ID: "slack", Params: map[string]any{}}}},
+	}
+	matchingIDs := map[string]bool{"rule-1": true, "rule-2": true, "rule-3": true, "rule-4": true, "rule-7": true}
+
+	// On create, nothing is removed
+	plan := planKibanaAlertingSummaryDigest(rules, matchingIDs, map[string]bool{}, action, nil)
+	if plan.skipped != 1 || len(plan.remove) != 0 {
+		t.Errorf("Unexpected plan on create: %+v", plan)
+	}
+	if len(plan.incompatible) != 1 || plan.incompatible[0] != "rule-7" {
+		t.Errorf("Expected rule with notify_when of rule reported as incompatible, got %s", plan.incompatible)
+	}
+	if len(plan.add) != 3 || plan.add[0] != "rule-2" || plan.add[1] != "rule-3" || plan.add[2] != "rule-4" {
+		t.Errorf("Unexpected rules to add on create: %s", plan.add)
+	}
+
+	// On update, the previous action and the action on rules that not match anymore are removed
+	managedIDs := map[string]bool{"rule-1": true, "rule-3": true, "rule-5": true}
+	oldAction := summaryAction(&otherThrottle)
+	plan = planKibanaAlertingSummaryDigest(rules, matchingIDs, managedIDs, action, []*kibanaAlertingRuleAction{&oldAction})
+	if plan.skipped != 1 {
+		t.Errorf("Expected 1 rule skipped, got %d", plan.skipped)
+	}
+	if len(plan.add) != 3 || plan.add[0] != "rule-2" || plan.add[1] != "rule-3" || plan.add[2] != "rule-4" {
+		t.Errorf("Unexpected rules to add on update: %s", plan.add)
+	}
+	if len(plan.remove) != 2 || plan.remove[0].ruleID != "rule-3" || plan.remove[1].ruleID != "rule-5" {
+		t.Fatalf("Unexpected rules to remove on update: %+v", plan.remove)
+	}
+	if rule5 := rules[4]; !plan.remove[1].match(&rule5.Actions[1]) || plan.remove[1].match(&rule5.Actions[0]) {
+		t.Error("Expected only summary action removed from rule-5")
+	}
+
+	// On delete, the action is removed from managed rules only
+	plan = planKibanaAlertingSummaryDigest(rules, map[string]bool{}, map[string]bool{"rule-1": true}, action, []*kibanaAlertingRuleAction{action})
+	if len(plan.add) != 0 || len(plan.remove) != 1 || plan.remove[0].ruleID != "rule-1" || !plan.remove[0].match(&rules[0].Actions[0]) {
+		t.Errorf("Unexpected plan on delete: %+v", plan)
+	}
+}
+
+func TestBuildKibanaAlertingRuleUpdateWithoutActions(t *testing.T) {
+	rule := map[string]any{}
+	if err := json.Unmarshal([]byte(`{
+		"id": "rule-1",
+		"name": "rule from UI",
+		"rule_type_id": ".es-query",
+		"consumer": "stackAlerts",
+		"enabled": true,
+		"tags": ["prod"],
+		"schedule": {"interval": "1m"},
+		"params": {"size": 100},
+		"notify_when": null,
+		"throttle": null,
+		"flapping": {"look_back_window": 20, "status_change_threshold": 4},
+		"alert_delay": {"active": 3},
+		"execution_status": {"status": "ok"},
+		"revision": 4,
+		"actions": [
+			{
+				"id": "pagerduty",
+				"connector_type_id": ".pagerduty",
+				"group": "query matched",
+				"uuid": "a1",
+				"params": {"summary": "{{alert.id}}"},
+				"frequency": {"summary": false, "notify_when": "onActionGroupChange", "throttle": null},
+				"alerts_filter": {"query": {"kql": "host.name:web-*", "filters": []}},
+				"use_alert_data_for_template": true
+			},
+			{
+				"id": "slack",
+				"connector_type_id": ".slack",
+				"group": "query matched",
+				"uuid": "a2",
+				"params": {"message": "{{alerts.new.count}} new alerts"},
+				"frequency": {"summary": true, "notify_when": "onThrottleInterval", "throttle": "1d"}
+			}
+		]
+	}`), &rule); err != nil {
+		t.Fatal(err)
+	}
+
+	body, removed, err := buildKibanaAlertingRuleUpdateWithoutActions(rule, func(action *kibanaAlertingRuleAction) bool {
+		return action.ID == "slack" && action.Frequency != nil && action.Frequency.Summary
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 action removed, got %d", removed)
+	}
+
+	// Fields not accepted by update API are removed
+	for _, field := range []string{"id", "rule_type_id", "consumer", "enabled", "execution_status", "revision", "notify_when", "throttle"} {
+		if _, ok := body[field]; ok {
+			t.Errorf("Expected field %s removed from update body", field)
+		}
+	}
+	// Fields not modeled by provider are kept
+	if !reflect.DeepEqual(body["flapping"], rule["flapping"]) || !reflect.DeepEqual(body["alert_delay"], rule["alert_delay"]) {
+		t.Errorf("Expected flapping and alert_delay kept, got %+v", body)
+	}
+	actions := body["actions"].([]any)
+	if len(actions) != 1 {
+		t.Fatalf("Expected 1 action kept, got %+v", actions)
+	}
+	action := actions[0].(map[string]any)
+	if action["uuid"] != "a1" || action["use_alert_data_for_template"] != true || !reflect.DeepEqual(action["alerts_filter"], rule["actions"].([]any)[0].(map[string]any)["alerts_filter"]) {
+		t.Errorf("Expected uuid, alerts_filter and use_alert_data_for_template kept, got %+v", action)
+	}
+	if _, ok := action["connector_type_id"]; ok {
+		t.Error("Expected connector_type_id removed from action")
+	}
+}
+
+var testKibanaAlertingSummaryDigest = `
+resource kibana_es_query_rule "test" {
+  name = "terraform-test-digest-typed"
+  tags = ["terraform-test-digest"]
+
+  dsl {
+    index      = ["terraform-test"]
+    time_field = "@timestamp"
+    query = jsonencode({
+      query = {
+        match_all = {}
+      }
+    })
+  }
+
+  threshold = [0]
+}
+
+resource kibana_alerting_summary_digest "test" {
+  name         = "terraform-test"
+  tags         = ["terraform-test-digest"]
+  connector_id = "terraform-test-digest"
+  params       = jsonencode({message = "{{alerts.new.count}} new alerts"})
+
+  depends_on = [kibana_es_query_rule.test]
+}
+`
+
+var testKibanaAlertingSummaryDigestTriggered = `
+resource kibana_es_query_rule "test" {
+  name = "terraform-test-digest-typed"
+  tags = ["terraform-test-digest"]
+
+  dsl {
+    index      = ["terraform-test"]
+    time_field = "@timestamp"
+    query = jsonencode({
+      query = {
+        match_all = {}
+      }
+    })
+  }
+
+  threshold = [0]
+}
+
+resource kibana_alerting_summary_digest "test" {
+  name         = "terraform-test"
+  tags         = ["terraform-test-digest"]
+  connector_id = "terraform-test-digest"
+  params       = jsonencode({message = "{{alerts.new.count}} new alerts"})
+
+  triggers = {
+    run = "2"
+  }
+
+  depends_on = [kibana_es_query_rule.test]
+}
+`
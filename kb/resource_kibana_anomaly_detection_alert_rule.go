@@ -0,0 +1,140 @@
+// Manage machine learning anomaly detection alert rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/machine-learning/current/ml-configuring-alerts.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Resource specification to handle anomaly detection alert rule in Kibana
+func resourceKibanaAnomalyDetectionAlertRule() *schema.Resource {
+	return resourceKibanaTypedRule(&kibanaTypedRule{
+		ruleTypeID:   "xpack.ml.anomaly_detection_alert",
+		consumer:     "alerts",
+		description:  "`kibana_anomaly_detection_alert_rule` manage an anomaly detection alert rule, that alert when machine learning jobs find anomalies above the severity threshold.",
+		actionGroups: []string{"anomaly_score_match"},
+		paramsSchema: map[string]*schema.Schema{
+			"job_ids": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{"job_ids", "group_ids"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"group_ids": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{"job_ids", "group_ids"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"severity": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      75,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"result_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "bucket",
+				ValidateFunc: validation.StringInSlice([]string{"bucket", "record", "influencer"}, false),
+			},
+			"include_interim": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"lookback_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[1-9][0-9]*[smhd]$`), "must be a duration like 1h"),
+			},
+			"top_n_buckets": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"kql_query": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		buildParams:   buildKibanaAnomalyDetectionAlertRuleParams,
+		flattenParams: flattenKibanaAnomalyDetectionAlertRuleParams,
+	})
+}
+
+// buildKibanaAnomalyDetectionAlertRuleParams permit to build the rule params from resource.
+// Kibana expect null when optional params are not set
+func buildKibanaAnomalyDetectionAlertRuleParams(d *schema.ResourceData) (map[string]any, error) {
+	params := map[string]any{
+		"jobSelection": map[string]any{
+			"jobIds":   convertArrayInterfaceToArrayString(d.Get("job_ids").(*schema.Set).List()),
+			"groupIds": convertArrayInterfaceToArrayString(d.Get("group_ids").(*schema.Set).List()),
+		},
+		"severity":         d.Get("severity").(int),
+		"resultType":       d.Get("result_type").(string),
+		"includeInterim":   d.Get("include_interim").(bool),
+		"lookbackInterval": nil,
+		"topNBuckets":      nil,
+		"kqlQueryString":   nil,
+	}
+	if lookbackInterval := d.Get("lookback_interval").(string); lookbackInterval != "" {
+		params["lookbackInterval"] = lookbackInterval
+	}
+	if topNBuckets := d.Get("top_n_buckets").(int); topNBuckets > 0 {
+		params["topNBuckets"] = topNBuckets
+	}
+	if kqlQuery := d.Get("kql_query").(string); kqlQuery != "" {
+		params["kqlQueryString"] = kqlQuery
+	}
+
+	return params, nil
+}
+
+// flattenKibanaAnomalyDetectionAlertRuleParams permit to set the rule params on resource
+func flattenKibanaAnomalyDetectionAlertRuleParams(d *schema.ResourceData, params map[string]any) error {
+	var err error
+
+	jobSelection, _ := params["jobSelection"].(map[string]any)
+	severity, _ := params["severity"].(float64)
+	topNBuckets, _ := params["topNBuckets"].(float64)
+
+	if err = d.Set("job_ids", jobSelection["jobIds"]); err != nil {
+		return err
+	}
+	if err = d.Set("group_ids", jobSelection["groupIds"]); err != nil {
+		return err
+	}
+	if err = d.Set("severity", int(severity)); err != nil {
+		return err
+	}
+	if err = d.Set("result_type", params["resultType"]); err != nil {
+		return err
+	}
+	if includeInterim, ok := params["includeInterim"].(bool); ok {
+		if err = d.Set("include_interim", includeInterim); err != nil {
+			return err
+		}
+	}
+	if err = d.Set("lookback_interval", params["lookbackInterval"]); err != nil {
+		return err
+	}
+	if err = d.Set("top_n_buckets", int(topNBuckets)); err != nil {
+		return err
+	}
+	if err = d.Set("kql_query", params["kqlQueryString"]); err != nil {
+		return err
+	}
+
+	return nil
+}
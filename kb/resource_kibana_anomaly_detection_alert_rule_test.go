@@ -0,0 +1,99 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccKibanaAnomalyDetectionAlertRule(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTypedRuleDestroy("kibana_anomaly_detection_alert_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaAnomalyDetectionAlertRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_anomaly_detection_alert_rule.test", "rule_id"),
+					resource.TestCheckResourceAttr("kibana_anomaly_detection_alert_rule.test", "severity", "75"),
+				),
+			},
+			{
+				Config: testKibanaAnomalyDetectionAlertRuleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_anomaly_detection_alert_rule.test", "result_type", "record"),
+					resource.TestCheckResourceAttr("kibana_anomaly_detection_alert_rule.test", "top_n_buckets", "3"),
+				),
+			},
+			{
+				ResourceName:      "kibana_anomaly_detection_alert_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestKibanaAnomalyDetectionAlertRuleParams(t *testing.T) {
+	resource := resourceKibanaAnomalyDetectionAlertRule()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":    "test",
+		"job_ids": []interface{}{"high_latency"},
+	})
+
+	params, err := buildKibanaAnomalyDetectionAlertRuleParams(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["severity"] != 75 || params["resultType"] != "bucket" {
+		t.Errorf("Expected default severity and result type, got %+v", params)
+	}
+	if value, ok := params["lookbackInterval"]; !ok || value != nil {
+		t.Errorf("Expected null lookbackInterval, got %+v", value)
+	}
+
+	// Kibana return params decoded from JSON
+	apiParams := map[string]any{
+		"jobSelection":     map[string]any{"jobIds": []any{}, "groupIds": []any{"apm"}},
+		"severity":         float64(50),
+		"resultType":       "influencer",
+		"includeInterim":   true,
+		"lookbackInterval": "2h",
+		"topNBuckets":      float64(2),
+		"kqlQueryString":   nil,
+	}
+	if err = flattenKibanaAnomalyDetectionAlertRuleParams(d, apiParams); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("job_ids").(*schema.Set).Len() != 0 || !d.Get("group_ids").(*schema.Set).Contains("apm") {
+		t.Errorf("Unexpected job selection: %+v %+v", d.Get("job_ids"), d.Get("group_ids"))
+	}
+	if d.Get("severity").(int) != 50 || d.Get("lookback_interval").(string) != "2h" || d.Get("top_n_buckets").(int) != 2 || d.Get("kql_query").(string) != "" {
+		t.Errorf("Unexpected params: %d %s %d %s", d.Get("severity").(int), d.Get("lookback_interval").(string), d.Get("top_n_buckets").(int), d.Get("kql_query").(string))
+	}
+}
+
+var testKibanaAnomalyDetectionAlertRule = `
+resource kibana_anomaly_detection_alert_rule "test" {
+  name     = "terraform-test"
+  interval = "15m"
+  job_ids  = ["terraform-test"]
+}
+`
+
+var testKibanaAnomalyDetectionAlertRuleUpdate = `
+resource kibana_anomaly_detection_alert_rule "test" {
+  name              = "terraform-test"
+  interval          = "15m"
+  job_ids           = ["terraform-test"]
+  severity          = 90
+  result_type       = "record"
+  lookback_interval = "1h"
+  top_n_buckets     = 3
+}
+`
@@ -0,0 +1,50 @@
+// Manage APM anomaly rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/observability/current/apm-alerts.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Resource specification to handle APM anomaly rule in Kibana
+// It need the APM machine learning jobs of the environment
+func resourceKibanaAPMAnomalyRule() *schema.Resource {
+	paramsSchema := kibanaAPMRuleServiceSchema()
+	paramsSchema["anomaly_severity_type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "critical",
+		ValidateFunc: validation.StringInSlice([]string{"critical", "major", "minor", "warning"}, false),
+	}
+
+	return resourceKibanaTypedRule(&kibanaTypedRule{
+		ruleTypeID:    "apm.anomaly",
+		consumer:      "apm",
+		description:   "`kibana_apm_anomaly_rule` manage an APM anomaly rule, that alert when the APM machine learning jobs find anomalies on service.",
+		actionGroups:  []string{"threshold_met"},
+		paramsSchema:  paramsSchema,
+		buildParams:   buildKibanaAPMAnomalyRuleParams,
+		flattenParams: flattenKibanaAPMAnomalyRuleParams,
+	})
+}
+
+// buildKibanaAPMAnomalyRuleParams permit to build the rule params from resource
+func buildKibanaAPMAnomalyRuleParams(d *schema.ResourceData) (map[string]any, error) {
+	params := buildKibanaAPMRuleService(d)
+	params["anomalySeverityType"] = d.Get("anomaly_severity_type").(string)
+
+	return params, nil
+}
+
+// flattenKibanaAPMAnomalyRuleParams permit to set the rule params on resource
+func flattenKibanaAPMAnomalyRuleParams(d *schema.ResourceData, params map[string]any) error {
+	if err := d.Set("anomaly_severity_type", params["anomalySeverityType"]); err != nil {
+		return err
+	}
+
+	return flattenKibanaAPMRuleService(d, params)
+}
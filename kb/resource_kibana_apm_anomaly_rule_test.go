@@ -0,0 +1,87 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccKibanaAPMAnomalyRule(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTypedRuleDestroy("kibana_apm_anomaly_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaAPMAnomalyRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_apm_anomaly_rule.test", "rule_id"),
+					resource.TestCheckResourceAttr("kibana_apm_anomaly_rule.test", "environment", "ENVIRONMENT_ALL"),
+				),
+			},
+			{
+				Config: testKibanaAPMAnomalyRuleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_apm_anomaly_rule.test", "environment", "production"),
+					resource.TestCheckResourceAttr("kibana_apm_anomaly_rule.test", "window_size", "15"),
+				),
+			},
+			{
+				ResourceName:      "kibana_apm_anomaly_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestKibanaAPMAnomalyRuleParams(t *testing.T) {
+	resource := resourceKibanaAPMAnomalyRule()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":             "test",
+		"transaction_type": "request",
+	})
+
+	params, err := buildKibanaAPMAnomalyRuleParams(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["anomalySeverityType"] != "critical" || params["transactionType"] != "request" || params["windowSize"] != 5 {
+		t.Errorf("Unexpected params: %+v", params)
+	}
+
+	// Kibana return params decoded from JSON
+	apiParams := map[string]any{
+		"environment":         "staging",
+		"anomalySeverityType": "minor",
+		"windowSize":          float64(30),
+		"windowUnit":          "m",
+	}
+	if err = flattenKibanaAPMAnomalyRuleParams(d, apiParams); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("anomaly_severity_type").(string) != "minor" || d.Get("environment").(string) != "staging" || d.Get("transaction_type").(string) != "" {
+		t.Errorf("Unexpected params: %s %s %s", d.Get("anomaly_severity_type").(string), d.Get("environment").(string), d.Get("transaction_type").(string))
+	}
+}
+
+var testKibanaAPMAnomalyRule = `
+resource kibana_apm_anomaly_rule "test" {
+  name         = "terraform-test"
+  service_name = "terraform-test"
+}
+`
+
+var testKibanaAPMAnomalyRuleUpdate = `
+resource kibana_apm_anomaly_rule "test" {
+  name                  = "terraform-test"
+  service_name          = "terraform-test"
+  environment           = "production"
+  anomaly_severity_type = "major"
+  window_size           = 15
+}
+`
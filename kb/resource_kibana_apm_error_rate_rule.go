@@ -0,0 +1,58 @@
+// Manage APM transaction error rate rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/observability/current/apm-alerts.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Resource specification to handle APM transaction error rate rule in Kibana
+func resourceKibanaAPMErrorRateRule() *schema.Resource {
+	paramsSchema := kibanaAPMRuleServiceSchema()
+	paramsSchema["transaction_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	paramsSchema["threshold"] = &schema.Schema{
+		Type:         schema.TypeFloat,
+		Required:     true,
+		ValidateFunc: validation.FloatBetween(0, 100),
+	}
+
+	return resourceKibanaTypedRule(&kibanaTypedRule{
+		ruleTypeID:    "apm.transaction_error_rate",
+		consumer:      "apm",
+		description:   "`kibana_apm_error_rate_rule` manage an APM transaction error rate rule, that alert when the percentage of failed transactions cross the threshold.",
+		actionGroups:  []string{"threshold_met"},
+		paramsSchema:  paramsSchema,
+		buildParams:   buildKibanaAPMErrorRateRuleParams,
+		flattenParams: flattenKibanaAPMErrorRateRuleParams,
+	})
+}
+
+// buildKibanaAPMErrorRateRuleParams permit to build the rule params from resource
+func buildKibanaAPMErrorRateRuleParams(d *schema.ResourceData) (map[string]any, error) {
+	params := buildKibanaAPMRuleService(d)
+	params["threshold"] = d.Get("threshold").(float64)
+	if transactionName := d.Get("transaction_name").(string); transactionName != "" {
+		params["transactionName"] = transactionName
+	}
+
+	return params, nil
+}
+
+// flattenKibanaAPMErrorRateRuleParams permit to set the rule params on resource
+func flattenKibanaAPMErrorRateRuleParams(d *schema.ResourceData, params map[string]any) error {
+	if err := d.Set("threshold", params["threshold"]); err != nil {
+		return err
+	}
+	if err := d.Set("transaction_name", params["transactionName"]); err != nil {
+		return err
+	}
+
+	return flattenKibanaAPMRuleService(d, params)
+}
@@ -0,0 +1,89 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccKibanaAPMErrorRateRule(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTypedRuleDestroy("kibana_apm_error_rate_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaAPMErrorRateRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_apm_error_rate_rule.test", "rule_id"),
+					resource.TestCheckResourceAttr("kibana_apm_error_rate_rule.test", "environment", "ENVIRONMENT_ALL"),
+				),
+			},
+			{
+				Config: testKibanaAPMErrorRateRuleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_apm_error_rate_rule.test", "environment", "production"),
+					resource.TestCheckResourceAttr("kibana_apm_error_rate_rule.test", "window_size", "15"),
+				),
+			},
+			{
+				ResourceName:      "kibana_apm_error_rate_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestKibanaAPMErrorRateRuleParams(t *testing.T) {
+	resource := resourceKibanaAPMErrorRateRule()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":             "test",
+		"transaction_name": "GET /api/cart",
+		"threshold":        2.5,
+	})
+
+	params, err := buildKibanaAPMErrorRateRuleParams(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["threshold"] != 2.5 || params["transactionName"] != "GET /api/cart" {
+		t.Errorf("Unexpected params: %+v", params)
+	}
+
+	// Kibana return params decoded from JSON
+	apiParams := map[string]any{
+		"environment": kibanaAPMEnvironmentAll,
+		"threshold":   float64(10),
+		"windowSize":  float64(5),
+		"windowUnit":  "m",
+	}
+	if err = flattenKibanaAPMErrorRateRuleParams(d, apiParams); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("threshold").(float64) != 10 || d.Get("transaction_name").(string) != "" {
+		t.Errorf("Unexpected threshold or transaction: %f %s", d.Get("threshold").(float64), d.Get("transaction_name").(string))
+	}
+}
+
+var testKibanaAPMErrorRateRule = `
+resource kibana_apm_error_rate_rule "test" {
+  name         = "terraform-test"
+  service_name = "terraform-test"
+  threshold    = 5
+}
+`
+
+var testKibanaAPMErrorRateRuleUpdate = `
+resource kibana_apm_error_rate_rule "test" {
+  name         = "terraform-test"
+  service_name = "terraform-test"
+  environment  = "production"
+  threshold    = 2.5
+  window_size  = 15
+}
+`
@@ -0,0 +1,134 @@
+// Manage the index settings of APM in Kibana
+// API documentation: not documented, internal API used by APM UI
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	kibanaAPMIndexSettingsID = "apm-index-settings" // APM index settings are global, so there are only one resource
+)
+
+// apmIndexSettingsMapping is the mapping between resource attribute and APM configuration name
+var apmIndexSettingsMapping = map[string]string{
+	"error_indices":       "error",
+	"onboarding_indices":  "onboarding",
+	"span_indices":        "span",
+	"transaction_indices": "transaction",
+	"metric_indices":      "metric",
+}
+
+// Resource specification to handle APM index settings in Kibana
+func resourceKibanaAPMIndexSettings() *schema.Resource {
+	resourceSchema := map[string]*schema.Schema{}
+	for attribute := range apmIndexSettingsMapping {
+		resourceSchema[attribute] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceKibanaAPMIndexSettingsCreate,
+		ReadContext:   resourceKibanaAPMIndexSettingsRead,
+		UpdateContext: resourceKibanaAPMIndexSettingsUpdate,
+		DeleteContext: resourceKibanaAPMIndexSettingsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: resourceSchema,
+	}
+}
+
+// Save APM index settings in Kibana
+func resourceKibanaAPMIndexSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*kibanaMeta).client
+
+	if err := saveKibanaAPMIndexSettings(client.Client, buildKibanaAPMIndexSettings(d)); err != nil {
+		return handleAPIError(err, "save APM index settings")
+	}
+
+	d.SetId(kibanaAPMIndexSettingsID)
+
+	log.Infof("Created APM index settings successfully")
+	fmt.Printf("[INFO] Created APM index settings successfully")
+
+	return resourceKibanaAPMIndexSettingsRead(ctx, d, meta)
+}
+
+// Read APM index settings in Kibana
+func resourceKibanaAPMIndexSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*kibanaMeta).client
+
+	settings, err := getKibanaAPMIndexSettings(client.Client)
+	if err != nil {
+		return handleAPIError(err, "read APM index settings")
+	}
+
+	log.Debugf("Get APM index settings successfully:\n%+v", settings)
+
+	for attribute, configurationName := range apmIndexSettingsMapping {
+		if err = d.Set(attribute, settings[configurationName]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Infof("Read APM index settings successfully")
+	fmt.Printf("[INFO] Read APM index settings successfully")
+
+	return nil
+}
+
+// Update APM index settings in Kibana
+func resourceKibanaAPMIndexSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*kibanaMeta).client
+
+	if err := saveKibanaAPMIndexSettings(client.Client, buildKibanaAPMIndexSettings(d)); err != nil {
+		return handleAPIError(err, "save APM index settings")
+	}
+
+	log.Infof("Updated APM index settings successfully")
+	fmt.Printf("[INFO] Updated APM index settings successfully")
+
+	return resourceKibanaAPMIndexSettingsRead(ctx, d, meta)
+}
+
+// Reset APM index settings to default values in Kibana
+func resourceKibanaAPMIndexSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*kibanaMeta).client
+
+	settings := map[string]string{}
+	for _, configurationName := range apmIndexSettingsMapping {
+		settings[configurationName] = ""
+	}
+	if err := saveKibanaAPMIndexSettings(client.Client, settings); err != nil {
+		return handleAPIError(err, "reset APM index settings")
+	}
+
+	d.SetId("")
+
+	log.Infof("Reset APM index settings successfully")
+	fmt.Printf("[INFO] Reset APM index settings successfully")
+	return nil
+}
+
+// buildKibanaAPMIndexSettings permit to build the APM index settings from resource
+func buildKibanaAPMIndexSettings(d *schema.ResourceData) map[string]string {
+	settings := map[string]string{}
+	for attribute, configurationName := range apmIndexSettingsMapping {
+		settings[configurationName] = d.Get(attribute).(string)
+	}
+
+	return settings
+}
@@ -0,0 +1,64 @@
+package kb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKibanaAPMIndexSettings(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaAPMIndexSettingsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaAPMIndexSettings,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_apm_index_settings.test", "error_indices", "logs-apm-test*"),
+					resource.TestCheckResourceAttr("kibana_apm_index_settings.test", "transaction_indices", "traces-apm-test*"),
+				),
+			},
+			{
+				ResourceName:      "kibana_apm_index_settings.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckKibanaAPMIndexSettingsDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kibana_apm_index_settings" {
+			continue
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		settings, err := getKibanaAPMIndexSettings(client.Client)
+		if err != nil {
+			return err
+		}
+		for name, value := range settings {
+			if value != "" {
+				return fmt.Errorf("APM index setting %s is still set to %s", name, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+var testKibanaAPMIndexSettings = `
+resource kibana_apm_index_settings "test" {
+  error_indices       = "logs-apm-test*"
+  transaction_indices = "traces-apm-test*"
+}
+`
@@ -0,0 +1,149 @@
+// Manage APM latency threshold rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/observability/current/apm-alerts.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	kibanaAPMEnvironmentAll = "ENVIRONMENT_ALL" // Environment used by APM rules to check all environments
+)
+
+// Resource specification to handle APM latency threshold rule in Kibana
+func resourceKibanaAPMLatencyRule() *schema.Resource {
+	paramsSchema := kibanaAPMRuleServiceSchema()
+	paramsSchema["transaction_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	paramsSchema["aggregation_type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "avg",
+		ValidateFunc: validation.StringInSlice([]string{"avg", "95th", "99th"}, false),
+	}
+	paramsSchema["threshold"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+
+	return resourceKibanaTypedRule(&kibanaTypedRule{
+		ruleTypeID:    "apm.transaction_duration",
+		consumer:      "apm",
+		description:   "`kibana_apm_latency_rule` manage an APM latency threshold rule, that alert when the latency of transactions cross the threshold.",
+		actionGroups:  []string{"threshold_met"},
+		paramsSchema:  paramsSchema,
+		buildParams:   buildKibanaAPMLatencyRuleParams,
+		flattenParams: flattenKibanaAPMLatencyRuleParams,
+	})
+}
+
+// buildKibanaAPMLatencyRuleParams permit to build the rule params from resource
+func buildKibanaAPMLatencyRuleParams(d *schema.ResourceData) (map[string]any, error) {
+	params := buildKibanaAPMRuleService(d)
+	params["aggregationType"] = d.Get("aggregation_type").(string)
+	params["threshold"] = d.Get("threshold").(int)
+	if transactionName := d.Get("transaction_name").(string); transactionName != "" {
+		params["transactionName"] = transactionName
+	}
+
+	return params, nil
+}
+
+// flattenKibanaAPMLatencyRuleParams permit to set the rule params on resource
+func flattenKibanaAPMLatencyRuleParams(d *schema.ResourceData, params map[string]any) error {
+	threshold, _ := params["threshold"].(float64)
+	if err := d.Set("aggregation_type", params["aggregationType"]); err != nil {
+		return err
+	}
+	if err := d.Set("threshold", int(threshold)); err != nil {
+		return err
+	}
+	if err := d.Set("transaction_name", params["transactionName"]); err != nil {
+		return err
+	}
+
+	return flattenKibanaAPMRuleService(d, params)
+}
+
+// kibanaAPMRuleServiceSchema return the schema to select service and time window, shared by APM rules
+func kibanaAPMRuleServiceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"service_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"environment": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  kibanaAPMEnvironmentAll,
+		},
+		"transaction_type": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"window_size": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      5,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"window_unit": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "m",
+			ValidateFunc: validation.StringInSlice([]string{"m", "h", "d"}, false),
+		},
+	}
+}
+
+// buildKibanaAPMRuleService permit to build the rule params that select service and time window.
+// Service and transaction type not set means all of them
+func buildKibanaAPMRuleService(d *schema.ResourceData) map[string]any {
+	params := map[string]any{
+		"environment": d.Get("environment").(string),
+		"windowSize":  d.Get("window_size").(int),
+		"windowUnit":  d.Get("window_unit").(string),
+	}
+	if serviceName := d.Get("service_name").(string); serviceName != "" {
+		params["serviceName"] = serviceName
+	}
+	if transactionType := d.Get("transaction_type").(string); transactionType != "" {
+		params["transactionType"] = transactionType
+	}
+
+	return params
+}
+
+// flattenKibanaAPMRuleService permit to set the service and time window on resource
+func flattenKibanaAPMRuleService(d *schema.ResourceData, params map[string]any) error {
+	var err error
+
+	windowSize, _ := params["windowSize"].(float64)
+
+	if err = d.Set("service_name", params["serviceName"]); err != nil {
+		return err
+	}
+	if environment, ok := params["environment"].(string); ok {
+		if err = d.Set("environment", environment); err != nil {
+			return err
+		}
+	}
+	if err = d.Set("transaction_type", params["transactionType"]); err != nil {
+		return err
+	}
+	if err = d.Set("window_size", int(windowSize)); err != nil {
+		return err
+	}
+	if err = d.Set("window_unit", params["windowUnit"]); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccKibanaAPMLatencyRule(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTypedRuleDestroy("kibana_apm_latency_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaAPMLatencyRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_apm_latency_rule.test", "rule_id"),
+					resource.TestCheckResourceAttr("kibana_apm_latency_rule.test", "environment", "ENVIRONMENT_ALL"),
+				),
+			},
+			{
+				Config: testKibanaAPMLatencyRuleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_apm_latency_rule.test", "environment", "production"),
+					resource.TestCheckResourceAttr("kibana_apm_latency_rule.test", "window_size", "15"),
+				),
+			},
+			{
+				ResourceName:      "kibana_apm_latency_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestKibanaAPMLatencyRuleParams(t *testing.T) {
+	resource := resourceKibanaAPMLatencyRule()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":             "test",
+		"service_name":     "checkout",
+		"aggregation_type": "95th",
+		"threshold":        1500,
+	})
+
+	params, err := buildKibanaAPMLatencyRuleParams(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["serviceName"] != "checkout" || params["environment"] != kibanaAPMEnvironmentAll || params["aggregationType"] != "95th" || params["threshold"] != 1500 {
+		t.Errorf("Unexpected params: %+v", params)
+	}
+	if _, ok := params["transactionType"]; ok {
+		t.Error("Expected no transactionType when not set, to check all transaction types")
+	}
+
+	// Kibana return params decoded from JSON
+	apiParams := map[string]any{
+		"serviceName":     "cart",
+		"environment":     "production",
+		"transactionType": "request",
+		"aggregationType": "99th",
+		"threshold":       float64(800),
+		"windowSize":      float64(10),
+		"windowUnit":      "m",
+	}
+	if err = flattenKibanaAPMLatencyRuleParams(d, apiParams); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("service_name").(string) != "cart" || d.Get("environment").(string) != "production" || d.Get("transaction_type").(string) != "request" {
+		t.Errorf("Unexpected service: %s %s %s", d.Get("service_name").(string), d.Get("environment").(string), d.Get("transaction_type").(string))
+	}
+	if d.Get("threshold").(int) != 800 || d.Get("window_size").(int) != 10 {
+		t.Errorf("Unexpected threshold or window: %d %d", d.Get("threshold").(int), d.Get("window_size").(int))
+	}
+}
+
+var testKibanaAPMLatencyRule = `
+resource kibana_apm_latency_rule "test" {
+  name         = "terraform-test"
+  service_name = "terraform-test"
+  threshold    = 1000
+}
+`
+
+var testKibanaAPMLatencyRuleUpdate = `
+resource kibana_apm_latency_rule "test" {
+  name             = "terraform-test"
+  service_name     = "terraform-test"
+  environment      = "production"
+  aggregation_type = "95th"
+  threshold        = 2000
+  window_size      = 15
+}
+`
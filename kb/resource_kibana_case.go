@@ -0,0 +1,339 @@
+// Manage the cases in Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/cases-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource specification to handle case in Kibana
+// Comments are not managed, so they never cause drift
+func resourceKibanaCase() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaCaseCreate,
+		ReadContext:   resourceKibanaCaseRead,
+		UpdateContext: resourceKibanaCaseUpdate,
+		DeleteContext: resourceKibanaCaseDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"owner": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "cases",
+				ValidateFunc: validation.StringInSlice([]string{"cases", "securitySolution", "observability"}, false),
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "open",
+				ValidateFunc: validation.StringInSlice([]string{"open", "in-progress", "closed"}, false),
+			},
+			"severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "low",
+				ValidateFunc: validation.StringInSlice([]string{"low", "medium", "high", "critical"}, false),
+			},
+			"assignees": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"sync_alerts": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"connector": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"fields": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"case_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Create new case in Kibana
+func resourceKibanaCaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+	kCase := buildKibanaCase(d)
+
+	client := meta.(*kibanaMeta).client
+
+	kCase, err := createKibanaCase(client.Client, space, kCase)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("create case %s", d.Get("title").(string)))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", space, kCase.ID))
+
+	// Status can't be set on creation
+	if status := d.Get("status").(string); status != kCase.Status {
+		update := buildKibanaCase(d)
+		update.ID = kCase.ID
+		update.Version = kCase.Version
+		if err = updateKibanaCase(client.Client, space, update); err != nil {
+			return handleAPIError(err, fmt.Sprintf("update case %s", d.Id()))
+		}
+	}
+
+	log.Infof("Created case %s successfully", d.Id())
+	fmt.Printf("[INFO] Created case %s successfully", d.Id())
+
+	return resourceKibanaCaseRead(ctx, d, meta)
+}
+
+// Read existing case in Kibana
+func resourceKibanaCaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var err error
+	id := d.Id()
+
+	log.Debugf("Case id: %s", id)
+
+	space, caseID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	kCase, err := getKibanaCase(client.Client, space, caseID)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("read case %s", id))
+	}
+
+	if kCase == nil {
+		log.Warnf("Case %s not found - removing from state", id)
+		fmt.Printf("[WARN] Case %s not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	log.Debugf("Get case %s successfully:\n%+v", id, kCase)
+
+	assignees := make([]string, 0, len(kCase.Assignees))
+	for _, assignee := range kCase.Assignees {
+		assignees = append(assignees, assignee.UID)
+	}
+
+	if err = d.Set("space", space); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("case_id", kCase.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("owner", kCase.Owner); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("title", kCase.Title); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("description", kCase.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("tags", kCase.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("status", kCase.Status); err != nil {
+		return diag.FromErr(err)
+	}
+	if kCase.Severity != "" {
+		if err = d.Set("severity", kCase.Severity); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err = d.Set("assignees", assignees); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("sync_alerts", kCase.Settings.SyncAlerts); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("connector", flattenKibanaCaseConnector(kCase.Connector)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read case %s successfully", id)
+	fmt.Printf("[INFO] Read case %s successfully", id)
+
+	return nil
+}
+
+// Update existing case in Kibana
+func resourceKibanaCaseUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	space, caseID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	// Case version change on each update, even when comment is added
+	current, err := getKibanaCase(client.Client, space, caseID)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("read case %s", id))
+	}
+	if current == nil {
+		return diag.Errorf("Case %s not found", id)
+	}
+
+	kCase := buildKibanaCase(d)
+	kCase.ID = caseID
+	kCase.Version = current.Version
+
+	if err = updateKibanaCase(client.Client, space, kCase); err != nil {
+		return handleAPIError(err, fmt.Sprintf("update case %s", id))
+	}
+
+	log.Infof("Updated case %s successfully", id)
+	fmt.Printf("[INFO] Updated case %s successfully", id)
+
+	return resourceKibanaCaseRead(ctx, d, meta)
+}
+
+// Delete existing case in Kibana
+func resourceKibanaCaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+	log.Debugf("Case id: %s", id)
+
+	space, caseID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if err = deleteKibanaCase(client.Client, space, caseID); err != nil {
+		if isAPIErrorNotFound(err) {
+			log.Warnf("Case %s not found - removing from state", id)
+			fmt.Printf("[WARN] Case %s not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return handleAPIError(err, fmt.Sprintf("delete case %s", id))
+	}
+
+	d.SetId("")
+
+	log.Infof("Deleted case %s successfully", id)
+	fmt.Printf("[INFO] Deleted case %s successfully", id)
+	return nil
+}
+
+// buildKibanaCase permit to build case from resource
+func buildKibanaCase(d *schema.ResourceData) *kibanaCase {
+	kCase := &kibanaCase{
+		Owner:       d.Get("owner").(string),
+		Title:       d.Get("title").(string),
+		Description: d.Get("description").(string),
+		Tags:        convertArrayInterfaceToArrayString(d.Get("tags").(*schema.Set).List()),
+		Status:      d.Get("status").(string),
+		Severity:    d.Get("severity").(string),
+		Assignees:   make([]kibanaCaseAssignee, 0),
+		Connector: kibanaCaseConnector{
+			ID:   "none",
+			Name: "none",
+			Type: ".none",
+		},
+		Settings: kibanaCaseSettings{
+			SyncAlerts: d.Get("sync_alerts").(bool),
+		},
+	}
+
+	for _, uid := range convertArrayInterfaceToArrayString(d.Get("assignees").(*schema.Set).List()) {
+		kCase.Assignees = append(kCase.Assignees, kibanaCaseAssignee{UID: uid})
+	}
+
+	if connectors := d.Get("connector").([]interface{}); len(connectors) > 0 && connectors[0] != nil {
+		m := connectors[0].(map[string]interface{})
+		kCase.Connector = kibanaCaseConnector{
+			ID:     m["id"].(string),
+			Name:   m["name"].(string),
+			Type:   m["type"].(string),
+			Fields: convertMapInterfaceToMapString(m["fields"].(map[string]interface{})),
+		}
+	}
+
+	return kCase
+}
+
+// flattenKibanaCaseConnector return nothing when case is not linked with connector
+func flattenKibanaCaseConnector(connector kibanaCaseConnector) []interface{} {
+	if connector.ID == "" || connector.ID == "none" {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"id":     connector.ID,
+			"name":   connector.Name,
+			"type":   connector.Type,
+			"fields": connector.Fields,
+		},
+	}
+}
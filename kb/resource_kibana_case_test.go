@@ -0,0 +1,87 @@
+package kb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKibanaCase(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaCaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaCase,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_case.test", "case_id"),
+					resource.TestCheckResourceAttr("kibana_case.test", "status", "in-progress"),
+					resource.TestCheckResourceAttr("kibana_case.test", "tags.#", "2"),
+				),
+			},
+			{
+				Config: testKibanaCaseUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_case.test", "title", "Terraform test updated"),
+					resource.TestCheckResourceAttr("kibana_case.test", "severity", "high"),
+				),
+			},
+			{
+				ResourceName:      "kibana_case.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckKibanaCaseDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kibana_case" {
+			continue
+		}
+
+		space, id, err := parseSpaceObjectID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		kCase, err := getKibanaCase(client.Client, space, id)
+		if err != nil {
+			return err
+		}
+		if kCase != nil {
+			return fmt.Errorf("Case %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testKibanaCase = `
+resource kibana_case "test" {
+  title       = "Terraform test"
+  description = "Long-lived case managed by Terraform"
+  tags        = ["terraform", "service-a"]
+  status      = "in-progress"
+}
+`
+
+var testKibanaCaseUpdate = `
+resource kibana_case "test" {
+  title       = "Terraform test updated"
+  description = "Long-lived case managed by Terraform"
+  tags        = ["terraform", "service-a"]
+  status      = "in-progress"
+  severity    = "high"
+}
+`
@@ -9,7 +9,6 @@ import (
 	"context"
 	"fmt"
 
-	kibana "github.com/disaster37/go-kibana-rest/v8"
 	"github.com/disaster37/go-kibana-rest/v8/kbapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -31,9 +30,9 @@ func resourceKibanaCopyObject() *schema.Resource {
 				ForceNew: true,
 			},
 			"source_space": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "default",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: defaultSpaceFunc(),
 			},
 			"target_spaces": {
 				Type:     schema.TypeSet,
@@ -88,7 +87,7 @@ func resourceKibanaCopyObjectCreate(ctx context.Context, d *schema.ResourceData,
 
 	err := copyObject(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleAPIError(err, fmt.Sprintf("copy objects %s", name))
 	}
 
 	d.SetId(name)
@@ -162,7 +161,7 @@ func resourceKibanaCopyObjectUpdate(ctx context.Context, d *schema.ResourceData,
 
 	err := copyObject(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleAPIError(err, fmt.Sprintf("copy objects %s", id))
 	}
 
 	log.Infof("Updated resource %s successfully", id)
@@ -216,7 +215,7 @@ func copyObject(d *schema.ResourceData, meta interface{}) error {
 	log.Debugf("Overwrite: %t", overwrite)
 	log.Debugf("CreateNewCopies: %t", createNewCopies)
 
-	client := meta.(*kibana.Client)
+	client := meta.(*kibanaMeta).client
 
 	objectsParameter := make([]kbapi.KibanaSpaceObjectParameter, 0, 1)
 	for _, object := range objects {
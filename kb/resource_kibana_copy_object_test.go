@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	kibana "github.com/disaster37/go-kibana-rest/v8"
 	"github.com/disaster37/go-kibana-rest/v8/kbapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -49,7 +48,7 @@ func testCheckKibanaCopyObjectExists(name string) resource.TestCheckFunc {
 
 		meta := testAccProvider.Meta()
 
-		client := meta.(*kibana.Client)
+		client := meta.(*kibanaMeta).client
 		data, err := client.API.KibanaSavedObject.Find(objectType, targetSpace, &kbapi.OptionalFindParameters{
 			Search: fmt.Sprintf("originId:\"%s\"", objectID),
 		})
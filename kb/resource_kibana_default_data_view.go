@@ -0,0 +1,131 @@
+// Manage the default data view of space in Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/data-views-api-set-default.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource specification to handle default data view in Kibana
+func resourceKibanaDefaultDataView() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaDefaultDataViewCreate,
+		ReadContext:   resourceKibanaDefaultDataViewRead,
+		UpdateContext: resourceKibanaDefaultDataViewUpdate,
+		DeleteContext: resourceKibanaDefaultDataViewDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"data_view_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+		},
+	}
+}
+
+// Set default data view in Kibana
+func resourceKibanaDefaultDataViewCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+	dataViewID := d.Get("data_view_id").(string)
+
+	client := meta.(*kibanaMeta).client
+
+	if err := setKibanaDefaultDataView(client.Client, space, dataViewID); err != nil {
+		return handleAPIError(err, "set default data view")
+	}
+
+	d.SetId(space)
+
+	log.Infof("Set default data view of space %s successfully", space)
+	fmt.Printf("[INFO] Set default data view of space %s successfully", space)
+
+	return resourceKibanaDefaultDataViewRead(ctx, d, meta)
+}
+
+// Read default data view in Kibana
+func resourceKibanaDefaultDataViewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var err error
+	id := d.Id()
+
+	log.Debugf("Default data view id: %s", id)
+
+	client := meta.(*kibanaMeta).client
+
+	dataViewID, err := getKibanaDefaultDataView(client.Client, id)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("read default data view %s", id))
+	}
+
+	if dataViewID == "" {
+		log.Warnf("Default data view %s not found - removing from state", id)
+		fmt.Printf("[WARN] Default data view %s not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("space", id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("data_view_id", dataViewID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read default data view %s successfully", id)
+	fmt.Printf("[INFO] Read default data view %s successfully", id)
+
+	return nil
+}
+
+// Update default data view in Kibana
+func resourceKibanaDefaultDataViewUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	client := meta.(*kibanaMeta).client
+
+	if err := setKibanaDefaultDataView(client.Client, id, d.Get("data_view_id").(string)); err != nil {
+		return handleAPIError(err, fmt.Sprintf("update default data view %s", id))
+	}
+
+	log.Infof("Updated default data view %s successfully", id)
+	fmt.Printf("[INFO] Updated default data view %s successfully", id)
+
+	return resourceKibanaDefaultDataViewRead(ctx, d, meta)
+}
+
+// Delete default data view in Kibana
+// The data view is kept, it's just not the default one anymore
+func resourceKibanaDefaultDataViewDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	client := meta.(*kibanaMeta).client
+
+	if err := setKibanaDefaultDataView(client.Client, id, ""); err != nil {
+		return handleAPIError(err, fmt.Sprintf("delete default data view %s", id))
+	}
+
+	d.SetId("")
+
+	log.Infof("Deleted default data view %s successfully", id)
+	fmt.Printf("[INFO] Deleted default data view %s successfully", id)
+	return nil
+}
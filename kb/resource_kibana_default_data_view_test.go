@@ -0,0 +1,104 @@
+package kb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKibanaDefaultDataView(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaDefaultDataViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaDefaultDataView,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_default_data_view.test", "id", "terraform-test-default-data-view"),
+					resource.TestCheckResourceAttr("kibana_default_data_view.test", "data_view_id", "terraform-test-logs"),
+				),
+			},
+			{
+				Config: testKibanaDefaultDataViewUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_default_data_view.test", "data_view_id", "terraform-test-metrics"),
+				),
+			},
+			{
+				ResourceName:      "kibana_default_data_view.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckKibanaDefaultDataViewDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kibana_default_data_view" {
+			continue
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		dataViewID, err := getKibanaDefaultDataView(client.Client, rs.Primary.ID)
+		if err != nil {
+			// The user space is destroyed too
+			continue
+		}
+		if dataViewID != "" {
+			return fmt.Errorf("Default data view %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testKibanaDefaultDataViewObjects = `
+resource kibana_user_space "test" {
+  uid  = "terraform-test-default-data-view"
+  name = "terraform-test-default-data-view"
+}
+
+resource kibana_object "test" {
+  name  = "terraform-test-default-data-view"
+  space = kibana_user_space.test.uid
+  data  = <<EOT
+{"attributes":{"title":"logs-*","timeFieldName":"@timestamp"},"id":"terraform-test-logs","type":"index-pattern"}
+{"attributes":{"title":"metrics-*","timeFieldName":"@timestamp"},"id":"terraform-test-metrics","type":"index-pattern"}
+EOT
+  export_objects {
+    id   = "terraform-test-logs"
+    type = "index-pattern"
+  }
+  export_objects {
+    id   = "terraform-test-metrics"
+    type = "index-pattern"
+  }
+}
+`
+
+var testKibanaDefaultDataView = testKibanaDefaultDataViewObjects + `
+resource kibana_default_data_view "test" {
+  space        = kibana_user_space.test.uid
+  data_view_id = "terraform-test-logs"
+
+  depends_on = [kibana_object.test]
+}
+`
+
+var testKibanaDefaultDataViewUpdate = testKibanaDefaultDataViewObjects + `
+resource kibana_default_data_view "test" {
+  space        = kibana_user_space.test.uid
+  data_view_id = "terraform-test-metrics"
+
+  depends_on = [kibana_object.test]
+}
+`
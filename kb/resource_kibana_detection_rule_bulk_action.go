@@ -0,0 +1,271 @@
+// Apply bulk action on detection rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/security/current/bulk-actions-rules-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource specification to apply bulk action on detection rules in Kibana
+func resourceKibanaDetectionRuleBulkAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaDetectionRuleBulkActionCreate,
+		ReadContext:   resourceKibanaDetectionRuleBulkActionRead,
+		UpdateContext: resourceKibanaDetectionRuleBulkActionUpdate,
+		DeleteContext: resourceKibanaDetectionRuleBulkActionDelete,
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// Values from other resources are only known on apply
+			for _, key := range []string{"action", "add_tags", "delete_tags", "timeline_id", "include_exceptions"} {
+				if !d.NewValueKnown(key) {
+					return nil
+				}
+			}
+			return validateKibanaDetectionRuleBulkAction(
+				d.Get("action").(string),
+				d.Get("add_tags").(*schema.Set).Len() > 0 || d.Get("delete_tags").(*schema.Set).Len() > 0 || d.Get("timeline_id").(string) != "",
+				d.Get("include_exceptions").(bool),
+			)
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enable", "disable", "duplicate", "edit"}, false),
+			},
+			"query": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ids"},
+			},
+			"ids": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"query"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"add_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"delete_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"timeline_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"timeline_title"},
+			},
+			"timeline_title": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"timeline_id"},
+			},
+			"include_exceptions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rules_succeeded": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rules_skipped": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Apply bulk action on detection rules
+func resourceKibanaDetectionRuleBulkActionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+
+	if diags := applyDetectionRuleBulkAction(d, meta); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(name)
+
+	log.Infof("Applied bulk action %s successfully", name)
+	fmt.Printf("[INFO] Applied bulk action %s successfully", name)
+
+	return resourceKibanaDetectionRuleBulkActionRead(ctx, d, meta)
+}
+
+// Read bulk action
+// The action is not saved on Kibana, so it keep the state as is
+func resourceKibanaDetectionRuleBulkActionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	if err := d.Set("name", id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read bulk action %s successfully", id)
+	fmt.Printf("[INFO] Read bulk action %s successfully", id)
+
+	return nil
+}
+
+// Apply again bulk action on detection rules
+func resourceKibanaDetectionRuleBulkActionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	if diags := applyDetectionRuleBulkAction(d, meta); diags.HasError() {
+		return diags
+	}
+
+	log.Infof("Applied again bulk action %s successfully", id)
+	fmt.Printf("[INFO] Applied again bulk action %s successfully", id)
+
+	return resourceKibanaDetectionRuleBulkActionRead(ctx, d, meta)
+}
+
+// Delete bulk action in Kibana is not supported
+// It just remove resource from state, the rules are kept as is
+func resourceKibanaDetectionRuleBulkActionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	d.SetId("")
+
+	log.Infof("Delete bulk action in not supported - just removing from state")
+	fmt.Printf("[INFO] Delete bulk action in not supported - just removing from state")
+	return nil
+}
+
+// applyDetectionRuleBulkAction permit to apply the bulk action and store the summary on resource
+func applyDetectionRuleBulkAction(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	space := d.Get("space").(string)
+
+	bulkAction, err := buildKibanaDetectionRuleBulkAction(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Debugf("Bulk action: %+v", bulkAction)
+
+	client := meta.(*kibanaMeta).client
+
+	result, err := bulkActionKibanaDetectionRules(client.Client, space, bulkAction)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("apply bulk action %s", name))
+	}
+	log.Debugf("Bulk action result: %+v", result)
+
+	if result.Failed > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to apply bulk action %s on %d rules", name, result.Failed),
+			Detail:   strings.Join(result.Errors, "\n"),
+		}}
+	}
+
+	if err = d.Set("rules_succeeded", result.Succeeded); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("rules_skipped", result.Skipped); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// validateKibanaDetectionRuleBulkAction permit to check arguments are used with the right action
+func validateKibanaDetectionRuleBulkAction(action string, hasEdit bool, includeExceptions bool) error {
+	if action == "edit" && !hasEdit {
+		return errors.New("action edit need at least one of add_tags, delete_tags or timeline_id")
+	}
+	if action != "edit" && hasEdit {
+		return fmt.Errorf("add_tags, delete_tags and timeline_id are only allowed with action edit, got %s", action)
+	}
+	if action != "duplicate" && includeExceptions {
+		return fmt.Errorf("include_exceptions is only allowed with action duplicate, got %s", action)
+	}
+
+	return nil
+}
+
+// buildKibanaDetectionRuleBulkAction permit to build bulk action from resource
+func buildKibanaDetectionRuleBulkAction(d *schema.ResourceData) (*kibanaDetectionRuleBulkAction, error) {
+	action := d.Get("action").(string)
+	bulkAction := &kibanaDetectionRuleBulkAction{
+		Action: action,
+		Query:  d.Get("query").(string),
+		IDs:    convertArrayInterfaceToArrayString(d.Get("ids").(*schema.Set).List()),
+		Edit:   make([]kibanaDetectionRuleBulkEdit, 0),
+	}
+
+	if tags := convertArrayInterfaceToArrayString(d.Get("add_tags").(*schema.Set).List()); len(tags) > 0 {
+		bulkAction.Edit = append(bulkAction.Edit, kibanaDetectionRuleBulkEdit{Type: "add_tags", Value: tags})
+	}
+	if tags := convertArrayInterfaceToArrayString(d.Get("delete_tags").(*schema.Set).List()); len(tags) > 0 {
+		bulkAction.Edit = append(bulkAction.Edit, kibanaDetectionRuleBulkEdit{Type: "delete_tags", Value: tags})
+	}
+	if timelineID := d.Get("timeline_id").(string); timelineID != "" {
+		bulkAction.Edit = append(bulkAction.Edit, kibanaDetectionRuleBulkEdit{
+			Type: "set_timeline",
+			Value: map[string]string{
+				"timeline_id":    timelineID,
+				"timeline_title": d.Get("timeline_title").(string),
+			},
+		})
+	}
+
+	switch action {
+	case "edit":
+		if len(bulkAction.Edit) == 0 {
+			return nil, errors.New("action edit need at least one of add_tags, delete_tags or timeline_id")
+		}
+	case "duplicate":
+		bulkAction.Duplicate = &kibanaDetectionRuleBulkDuplication{
+			IncludeExceptions: d.Get("include_exceptions").(bool),
+		}
+		fallthrough
+	default:
+		if len(bulkAction.Edit) > 0 {
+			return nil, errors.Errorf("add_tags, delete_tags and timeline_id can only be used with action edit, got %s", action)
+		}
+	}
+
+	return bulkAction, nil
+}
@@ -0,0 +1,114 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccKibanaDetectionRuleBulkAction(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaDetectionRuleBulkAction,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_detection_rule_bulk_action.test", "rules_succeeded"),
+				),
+			},
+		},
+	})
+}
+
+func TestBuildKibanaDetectionRuleBulkAction(t *testing.T) {
+	r := resourceKibanaDetectionRuleBulkAction()
+
+	// Edit action with tags and timeline
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]any{
+		"name":           "test",
+		"action":         "edit",
+		"query":          `alert.attributes.tags:"Elastic"`,
+		"add_tags":       []any{"team-soc"},
+		"timeline_id":    "timeline-1",
+		"timeline_title": "Generic timeline",
+	})
+	bulkAction, err := buildKibanaDetectionRuleBulkAction(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bulkAction.Edit) != 2 || bulkAction.Edit[0].Type != "add_tags" || bulkAction.Edit[1].Type != "set_timeline" {
+		t.Errorf("Unexpected edit: %+v", bulkAction.Edit)
+	}
+
+	// Edit action need edition
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]any{
+		"name":   "test",
+		"action": "edit",
+	})
+	if _, err = buildKibanaDetectionRuleBulkAction(d); err == nil {
+		t.Error("Expected error when edit action without edition")
+	}
+
+	// Tags can only be used with edit action
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]any{
+		"name":     "test",
+		"action":   "duplicate",
+		"add_tags": []any{"team-soc"},
+	})
+	if _, err = buildKibanaDetectionRuleBulkAction(d); err == nil {
+		t.Error("Expected error when duplicate action with tags")
+	}
+
+	// Duplicate action
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]any{
+		"name":               "test",
+		"action":             "duplicate",
+		"ids":                []any{"rule-1"},
+		"include_exceptions": true,
+	})
+	bulkAction, err = buildKibanaDetectionRuleBulkAction(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bulkAction.Duplicate == nil || !bulkAction.Duplicate.IncludeExceptions {
+		t.Errorf("Expected duplicate options, got %+v", bulkAction.Duplicate)
+	}
+}
+
+func TestValidateKibanaDetectionRuleBulkAction(t *testing.T) {
+	if err := validateKibanaDetectionRuleBulkAction("edit", true, false); err != nil {
+		t.Errorf("Expected edit action with edition to be valid, got %s", err)
+	}
+	if err := validateKibanaDetectionRuleBulkAction("duplicate", false, true); err != nil {
+		t.Errorf("Expected duplicate action with exceptions to be valid, got %s", err)
+	}
+	if err := validateKibanaDetectionRuleBulkAction("edit", false, false); err == nil {
+		t.Error("Expected error when edit action without edition")
+	}
+	if err := validateKibanaDetectionRuleBulkAction("enable", true, false); err == nil {
+		t.Error("Expected error when enable action with edition")
+	}
+	if err := validateKibanaDetectionRuleBulkAction("edit", true, true); err == nil {
+		t.Error("Expected error when edit action with include_exceptions")
+	}
+}
+
+var testKibanaDetectionRuleBulkAction = `
+resource kibana_detection_rules_prepackaged "test" {
+  space = "default"
+}
+
+resource kibana_detection_rule_bulk_action "test" {
+  name     = "terraform-test"
+  action   = "edit"
+  query    = "alert.attributes.tags:\"Elastic\" and alert.attributes.tags:\"Windows\""
+  add_tags = ["terraform-test"]
+
+  depends_on = [kibana_detection_rules_prepackaged.test]
+}
+`
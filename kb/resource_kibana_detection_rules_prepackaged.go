@@ -0,0 +1,213 @@
+// Install and update the Elastic prebuilt detection rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/security/current/rules-api-prebuilt.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource specification to handle Elastic prebuilt detection rules in Kibana
+func resourceKibanaDetectionRulesPrepackaged() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaDetectionRulesPrepackagedCreate,
+		ReadContext:   resourceKibanaDetectionRulesPrepackagedRead,
+		UpdateContext: resourceKibanaDetectionRulesPrepackagedUpdate,
+		DeleteContext: resourceKibanaDetectionRulesPrepackagedDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		// Install missing or outdated prebuilt rules on next apply
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if d.Id() == "" {
+				return nil
+			}
+			if d.Get("rules_not_installed").(int) > 0 || d.Get("rules_not_updated").(int) > 0 ||
+				d.Get("timelines_not_installed").(int) > 0 || d.Get("timelines_not_updated").(int) > 0 {
+				for _, key := range []string{"rules_installed", "rules_not_installed", "rules_not_updated", "timelines_installed", "timelines_not_installed", "timelines_not_updated"} {
+					if err := d.SetNewComputed(key); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		},
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"package_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"installed_package_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rules_installed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rules_not_installed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rules_not_updated": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rules_custom_installed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"timelines_installed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"timelines_not_installed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"timelines_not_updated": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Install prebuilt rules in Kibana
+func resourceKibanaDetectionRulesPrepackagedCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+
+	if err := installPrepackagedRules(d, meta); err != nil {
+		return handleAPIError(err, fmt.Sprintf("install prebuilt rules in space %s", space))
+	}
+
+	d.SetId(space)
+
+	log.Infof("Installed prebuilt rules in space %s successfully", space)
+	fmt.Printf("[INFO] Installed prebuilt rules in space %s successfully", space)
+
+	return resourceKibanaDetectionRulesPrepackagedRead(ctx, d, meta)
+}
+
+// Read prebuilt rules status in Kibana
+func resourceKibanaDetectionRulesPrepackagedRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var err error
+	space := d.Id()
+
+	client := meta.(*kibanaMeta).client
+
+	status, err := getKibanaPrepackagedRulesStatus(client.Client, space)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("read prebuilt rules status in space %s", space))
+	}
+	log.Debugf("Get prebuilt rules status in space %s successfully:\n%+v", space, status)
+
+	installedVersion, err := getKibanaFleetPackageInstalledVersion(client.Client, kibanaDetectionEnginePackageName)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("read %s package", kibanaDetectionEnginePackageName))
+	}
+
+	if err = d.Set("space", space); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("installed_package_version", installedVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	// The pinned version is not returned by API, so only detect drift when package is installed with another version
+	if d.Get("package_version").(string) != "" {
+		if err = d.Set("package_version", installedVersion); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err = d.Set("rules_installed", status.RulesInstalled); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("rules_not_installed", status.RulesNotInstalled); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("rules_not_updated", status.RulesNotUpdated); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("rules_custom_installed", status.RulesCustomInstalled); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("timelines_installed", status.TimelinesInstalled); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("timelines_not_installed", status.TimelinesNotInstalled); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("timelines_not_updated", status.TimelinesNotUpdated); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read prebuilt rules status in space %s successfully", space)
+	fmt.Printf("[INFO] Read prebuilt rules status in space %s successfully", space)
+
+	return nil
+}
+
+// Update prebuilt rules in Kibana
+func resourceKibanaDetectionRulesPrepackagedUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Id()
+
+	if err := installPrepackagedRules(d, meta); err != nil {
+		return handleAPIError(err, fmt.Sprintf("update prebuilt rules in space %s", space))
+	}
+
+	log.Infof("Updated prebuilt rules in space %s successfully", space)
+	fmt.Printf("[INFO] Updated prebuilt rules in space %s successfully", space)
+
+	return resourceKibanaDetectionRulesPrepackagedRead(ctx, d, meta)
+}
+
+// Delete prebuilt rules in Kibana is not supported
+// It just remove resource from state, the installed rules are kept
+func resourceKibanaDetectionRulesPrepackagedDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	d.SetId("")
+
+	log.Infof("Delete prebuilt rules in not supported - just removing from state")
+	fmt.Printf("[INFO] Delete prebuilt rules in not supported - just removing from state")
+	return nil
+}
+
+// installPrepackagedRules permit to install the pinned version of detection engine package, then install prebuilt rules in space
+func installPrepackagedRules(d *schema.ResourceData, meta interface{}) error {
+	space := d.Get("space").(string)
+	packageVersion := d.Get("package_version").(string)
+
+	client := meta.(*kibanaMeta).client
+
+	if packageVersion != "" {
+		installedVersion, err := getKibanaFleetPackageInstalledVersion(client.Client, kibanaDetectionEnginePackageName)
+		if err != nil {
+			return err
+		}
+		if installedVersion != packageVersion {
+			log.Debugf("Install %s package %s (installed: %s)", kibanaDetectionEnginePackageName, packageVersion, installedVersion)
+			if err = installKibanaFleetPackage(client.Client, kibanaDetectionEnginePackageName, packageVersion); err != nil {
+				return err
+			}
+		}
+	}
+
+	return installKibanaPrepackagedRules(client.Client, space)
+}
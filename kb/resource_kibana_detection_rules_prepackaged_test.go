@@ -0,0 +1,65 @@
+package kb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKibanaDetectionRulesPrepackaged(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaDetectionRulesPrepackaged,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckKibanaDetectionRulesPrepackagedInstalled("kibana_detection_rules_prepackaged.test"),
+					resource.TestCheckResourceAttr("kibana_detection_rules_prepackaged.test", "rules_not_installed", "0"),
+					resource.TestCheckResourceAttr("kibana_detection_rules_prepackaged.test", "rules_not_updated", "0"),
+				),
+			},
+			{
+				ResourceName:      "kibana_detection_rules_prepackaged.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckKibanaDetectionRulesPrepackagedInstalled(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No space is set")
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		status, err := getKibanaPrepackagedRulesStatus(client.Client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if status.RulesInstalled == 0 {
+			return fmt.Errorf("No prebuilt rules installed in space %s", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+var testKibanaDetectionRulesPrepackaged = `
+resource kibana_detection_rules_prepackaged "test" {
+  space = "default"
+}
+`
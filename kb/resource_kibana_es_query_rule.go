@@ -0,0 +1,317 @@
+// Manage Elasticsearch query rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/rule-type-es-query.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Resource specification to handle Elasticsearch query rule in Kibana
+// The query can be a query DSL, a KQL or Lucene query on data view, or an ES|QL query
+func resourceKibanaESQueryRule() *schema.Resource {
+	return resourceKibanaTypedRule(&kibanaTypedRule{
+		ruleTypeID:   ".es-query",
+		consumer:     "alerts",
+		description:  "`kibana_es_query_rule` manage an Elasticsearch query rule, that alert when the number of documents matching the query cross the threshold.",
+		actionGroups: []string{"query matched"},
+		paramsSchema: map[string]*schema.Schema{
+			"dsl": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"dsl", "kql", "esql"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"time_field": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"query": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: suppressEquivalentJSON,
+						},
+					},
+				},
+			},
+			"kql": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"dsl", "kql", "esql"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"data_view_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"query": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"language": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "kuery",
+							ValidateFunc: validation.StringInSlice([]string{"kuery", "lucene"}, false),
+						},
+					},
+				},
+			},
+			"esql": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"dsl", "kql", "esql"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"query": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"time_field": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"validate": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(0, 10000),
+			},
+			"time_window_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"time_window_unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "m",
+				ValidateFunc: validation.StringInSlice([]string{"s", "m", "h", "d"}, false),
+			},
+			"threshold_comparator": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      ">",
+				ValidateFunc: validation.StringInSlice([]string{">", ">=", "<", "<=", "between", "notBetween"}, false),
+			},
+			"threshold": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MinItems: 1,
+				MaxItems: 2,
+				Elem: &schema.Schema{
+					Type: schema.TypeFloat,
+				},
+			},
+			"exclude_hits_from_previous_run": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+		buildParams:   buildKibanaESQueryRuleParams,
+		flattenParams: flattenKibanaESQueryRuleParams,
+		customizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// Threshold is computed for ES|QL rule, so read it from config
+			config := d.GetRawConfig()
+			if config.IsNull() || !config.GetAttr("threshold").IsKnown() {
+				return nil
+			}
+			nbThresholds := 0
+			if threshold := config.GetAttr("threshold"); !threshold.IsNull() {
+				nbThresholds = threshold.LengthInt()
+			}
+			if err := validateKibanaESQueryRule(
+				len(d.Get("esql").([]interface{})) > 0,
+				d.Get("threshold_comparator").(string),
+				nbThresholds,
+			); err != nil {
+				return err
+			}
+
+			// Check ES|QL query on Elasticsearch, only when it changed to not slow down each plan
+			if !d.Get("esql.0.validate").(bool) || !d.NewValueKnown("esql.0.query") || !d.HasChange("esql.0.query") {
+				return nil
+			}
+			client := meta.(*kibanaMeta).client
+			if err := validateKibanaESQLQuery(client.Client, d.Get("esql.0.query").(string)); err != nil {
+				return fmt.Errorf("ES|QL query is invalid: %s", err.Error())
+			}
+			return nil
+		},
+	})
+}
+
+// validateKibanaESQueryRule permit to check the threshold, like Kibana do on create.
+// ES|QL rule alert when query return rows, so Kibana only accept threshold > 0
+func validateKibanaESQueryRule(isESQL bool, comparator string, nbThresholds int) error {
+	if isESQL {
+		if comparator != ">" || nbThresholds > 1 {
+			return fmt.Errorf("ES|QL rule only support threshold_comparator > with threshold [0]")
+		}
+		return nil
+	}
+
+	switch comparator {
+	case "between", "notBetween":
+		if nbThresholds != 2 {
+			return fmt.Errorf("threshold must have 2 values when threshold_comparator is %s", comparator)
+		}
+	default:
+		if nbThresholds != 1 {
+			return fmt.Errorf("threshold must have 1 value when threshold_comparator is %s", comparator)
+		}
+	}
+
+	return nil
+}
+
+// buildKibanaESQueryRuleParams permit to build the rule params from resource
+func buildKibanaESQueryRuleParams(d *schema.ResourceData) (map[string]any, error) {
+	thresholds := make([]float64, 0, 2)
+	for _, threshold := range d.Get("threshold").([]interface{}) {
+		thresholds = append(thresholds, threshold.(float64))
+	}
+
+	params := map[string]any{
+		"size":                       d.Get("size").(int),
+		"timeWindowSize":             d.Get("time_window_size").(int),
+		"timeWindowUnit":             d.Get("time_window_unit").(string),
+		"thresholdComparator":        d.Get("threshold_comparator").(string),
+		"threshold":                  thresholds,
+		"excludeHitsFromPreviousRun": d.Get("exclude_hits_from_previous_run").(bool),
+	}
+
+	if dsl := d.Get("dsl").([]interface{}); len(dsl) > 0 && dsl[0] != nil {
+		m := dsl[0].(map[string]interface{})
+		params["searchType"] = "esQuery"
+		params["index"] = convertArrayInterfaceToArrayString(m["index"].([]interface{}))
+		params["timeField"] = m["time_field"].(string)
+		params["esQuery"] = m["query"].(string)
+	} else if kql := d.Get("kql").([]interface{}); len(kql) > 0 && kql[0] != nil {
+		m := kql[0].(map[string]interface{})
+		params["searchType"] = "searchSource"
+		params["searchConfiguration"] = map[string]any{
+			"index": m["data_view_id"].(string),
+			"query": map[string]any{
+				"query":    m["query"].(string),
+				"language": m["language"].(string),
+			},
+		}
+	} else if esql := d.Get("esql").([]interface{}); len(esql) > 0 && esql[0] != nil {
+		m := esql[0].(map[string]interface{})
+		params["searchType"] = "esqlQuery"
+		params["esqlQuery"] = map[string]any{
+			"esql": m["query"].(string),
+		}
+		params["timeField"] = m["time_field"].(string)
+		params["threshold"] = []float64{0}
+	}
+
+	return params, nil
+}
+
+// flattenKibanaESQueryRuleParams permit to set the rule params on resource
+func flattenKibanaESQueryRuleParams(d *schema.ResourceData, params map[string]any) error {
+	var err error
+
+	dsl := make([]interface{}, 0, 1)
+	kql := make([]interface{}, 0, 1)
+	esql := make([]interface{}, 0, 1)
+	switch params["searchType"] {
+	case "searchSource":
+		searchConfiguration, _ := params["searchConfiguration"].(map[string]any)
+		query, _ := searchConfiguration["query"].(map[string]any)
+		kql = append(kql, map[string]interface{}{
+			"data_view_id": searchConfiguration["index"],
+			"query":        query["query"],
+			"language":     query["language"],
+		})
+	case "esqlQuery":
+		esqlQuery, _ := params["esqlQuery"].(map[string]any)
+		esql = append(esql, map[string]interface{}{
+			"query":      esqlQuery["esql"],
+			"time_field": params["timeField"],
+			"validate":   d.Get("esql.0.validate"),
+		})
+	default:
+		// esQuery is a JSON string, but keep it safe if Kibana return it as object
+		query, ok := params["esQuery"].(string)
+		if !ok {
+			b, err := json.Marshal(params["esQuery"])
+			if err != nil {
+				return err
+			}
+			query = string(b)
+		}
+		dsl = append(dsl, map[string]interface{}{
+			"index":      params["index"],
+			"time_field": params["timeField"],
+			"query":      query,
+		})
+	}
+
+	size, _ := params["size"].(float64)
+	timeWindowSize, _ := params["timeWindowSize"].(float64)
+
+	if err = d.Set("dsl", dsl); err != nil {
+		return err
+	}
+	if err = d.Set("kql", kql); err != nil {
+		return err
+	}
+	if err = d.Set("esql", esql); err != nil {
+		return err
+	}
+	if err = d.Set("size", int(size)); err != nil {
+		return err
+	}
+	if err = d.Set("time_window_size", int(timeWindowSize)); err != nil {
+		return err
+	}
+	if err = d.Set("time_window_unit", params["timeWindowUnit"]); err != nil {
+		return err
+	}
+	if err = d.Set("threshold_comparator", params["thresholdComparator"]); err != nil {
+		return err
+	}
+	if err = d.Set("threshold", params["threshold"]); err != nil {
+		return err
+	}
+	if excludeHits, ok := params["excludeHitsFromPreviousRun"].(bool); ok {
+		if err = d.Set("exclude_hits_from_previous_run", excludeHits); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
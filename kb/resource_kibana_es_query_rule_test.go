@@ -0,0 +1,208 @@
+package kb
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccKibanaESQueryRule(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTypedRuleDestroy("kibana_es_query_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaESQueryRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_es_query_rule.test", "rule_id"),
+					resource.TestCheckResourceAttr("kibana_es_query_rule.test", "dsl.0.time_field", "@timestamp"),
+				),
+			},
+			{
+				Config: testKibanaESQueryRuleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_es_query_rule.test", "size", "10"),
+					resource.TestCheckResourceAttr("kibana_es_query_rule.test", "threshold.0", "10"),
+				),
+			},
+			{
+				ResourceName:      "kibana_es_query_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestValidateKibanaESQueryRule(t *testing.T) {
+	if err := validateKibanaESQueryRule(false, ">", 1); err != nil {
+		t.Errorf("Expected valid query rule, got %s", err)
+	}
+	if err := validateKibanaESQueryRule(false, ">", 0); err == nil {
+		t.Error("Expected error when threshold is missing")
+	}
+	if err := validateKibanaESQueryRule(false, "notBetween", 1); err == nil {
+		t.Error("Expected error when notBetween has only one threshold")
+	}
+	if err := validateKibanaESQueryRule(true, ">", 0); err != nil {
+		t.Errorf("Expected valid ES|QL rule, got %s", err)
+	}
+	if err := validateKibanaESQueryRule(true, "<", 1); err == nil {
+		t.Error("Expected error when ES|QL rule use other comparator")
+	}
+}
+
+func TestValidateKibanaESQLQuery(t *testing.T) {
+	queries := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/console/proxy" || r.URL.Query().Get("path") != "/_query" || r.URL.Query().Get("method") != "POST" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body := struct {
+			Query string `json:"query"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		queries = append(queries, body.Query)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body.Query, "WHERE") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"type":"verification_exception","reason":"Found 1 problem\nline 1:23: Unknown column [foo]"},"status":400}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"columns":[{"name":"count","type":"long"}],"values":[]}`))
+	}))
+	defer server.Close()
+	client := resty.New().SetBaseURL(server.URL)
+
+	if err := validateKibanaESQLQuery(client, "FROM logs-* | STATS count = COUNT(*) |"); err != nil {
+		t.Errorf("Expected valid ES|QL query, got %s", err)
+	}
+	if queries[0] != "FROM logs-* | STATS count = COUNT(*)\n| LIMIT 0" {
+		t.Errorf("Expected query with LIMIT 0, got %s", queries[0])
+	}
+
+	err := validateKibanaESQLQuery(client, "FROM logs-* | WHERE foo == 1")
+	if err == nil {
+		t.Fatal("Expected error on invalid ES|QL query")
+	}
+	if !strings.Contains(err.Error(), "verification_exception: Found 1 problem") {
+		t.Errorf("Expected Elasticsearch reason on error, got %s", err.Error())
+	}
+}
+
+func TestKibanaESQueryRuleParams(t *testing.T) {
+	resource := resourceKibanaESQueryRule()
+
+	// ES|QL
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "test",
+		"esql": []interface{}{
+			map[string]interface{}{
+				"query":      "FROM logs-* | WHERE log.level == \"error\"",
+				"time_field": "@timestamp",
+			},
+		},
+	})
+	params, err := buildKibanaESQueryRuleParams(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["searchType"] != "esqlQuery" || params["esqlQuery"].(map[string]any)["esql"] != "FROM logs-* | WHERE log.level == \"error\"" {
+		t.Errorf("Unexpected ES|QL params: %+v", params)
+	}
+	if thresholds := params["threshold"].([]float64); len(thresholds) != 1 || thresholds[0] != 0 {
+		t.Errorf("Expected threshold [0] for ES|QL, got %+v", params["threshold"])
+	}
+
+	// KQL on data view
+	d = schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "test",
+		"kql": []interface{}{
+			map[string]interface{}{
+				"data_view_id": "logs",
+				"query":        "log.level : error",
+			},
+		},
+		"threshold": []interface{}{10.0},
+	})
+	params, err = buildKibanaESQueryRuleParams(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["searchType"] != "searchSource" || params["searchConfiguration"].(map[string]any)["index"] != "logs" {
+		t.Errorf("Unexpected search source params: %+v", params)
+	}
+
+	// Kibana return params decoded from JSON
+	apiParams := map[string]any{
+		"searchType": "searchSource",
+		"searchConfiguration": map[string]any{
+			"index": "metrics",
+			"query": map[string]any{"query": "status : 500", "language": "lucene"},
+		},
+		"size":                float64(0),
+		"timeWindowSize":      float64(1),
+		"timeWindowUnit":      "h",
+		"thresholdComparator": ">=",
+		"threshold":           []any{float64(3)},
+	}
+	if err = flattenKibanaESQueryRuleParams(d, apiParams); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("kql.0.data_view_id").(string) != "metrics" || d.Get("kql.0.language").(string) != "lucene" || len(d.Get("dsl").([]interface{})) != 0 {
+		t.Errorf("Unexpected query: %+v %+v", d.Get("kql"), d.Get("dsl"))
+	}
+	if d.Get("time_window_unit").(string) != "h" || d.Get("threshold.0").(float64) != 3 {
+		t.Errorf("Unexpected time window or threshold: %s %+v", d.Get("time_window_unit").(string), d.Get("threshold"))
+	}
+}
+
+var testKibanaESQueryRule = `
+resource kibana_es_query_rule "test" {
+  name = "terraform-test"
+
+  dsl {
+    index      = ["terraform-test"]
+    time_field = "@timestamp"
+    query = jsonencode({
+      query = {
+        match_all = {}
+      }
+    })
+  }
+
+  threshold = [0]
+}
+`
+
+var testKibanaESQueryRuleUpdate = `
+resource kibana_es_query_rule "test" {
+  name = "terraform-test"
+
+  dsl {
+    index      = ["terraform-test"]
+    time_field = "@timestamp"
+    query = jsonencode({
+      query = {
+        match_all = {}
+      }
+    })
+  }
+
+  size      = 10
+  threshold = [10]
+}
+`
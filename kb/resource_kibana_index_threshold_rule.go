@@ -0,0 +1,214 @@
+// Manage index threshold rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/rule-type-index-threshold.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Resource specification to handle index threshold rule in Kibana
+func resourceKibanaIndexThresholdRule() *schema.Resource {
+	return resourceKibanaTypedRule(&kibanaTypedRule{
+		ruleTypeID:   ".index-threshold",
+		consumer:     "alerts",
+		description:  "`kibana_index_threshold_rule` manage an index threshold rule, that alert when an aggregation on indices cross the threshold.",
+		actionGroups: []string{"threshold met"},
+		paramsSchema: map[string]*schema.Schema{
+			"index": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"time_field": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"agg_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "count",
+				ValidateFunc: validation.StringInSlice([]string{"count", "avg", "min", "max", "sum"}, false),
+			},
+			"agg_field": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"group_by": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validation.StringInSlice([]string{"all", "top"}, false),
+			},
+			"term_field": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"term_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 10000),
+			},
+			"time_window_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"time_window_unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "m",
+				ValidateFunc: validation.StringInSlice([]string{"s", "m", "h", "d"}, false),
+			},
+			"threshold_comparator": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{">", ">=", "<", "<=", "between", "notBetween"}, false),
+			},
+			"threshold": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 2,
+				Elem: &schema.Schema{
+					Type: schema.TypeFloat,
+				},
+			},
+			"filter_kuery": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		buildParams:   buildKibanaIndexThresholdRuleParams,
+		flattenParams: flattenKibanaIndexThresholdRuleParams,
+		customizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// Values from other resources are only known on apply
+			for _, key := range []string{"agg_field", "term_field", "term_size", "threshold"} {
+				if !d.NewValueKnown(key) {
+					return nil
+				}
+			}
+			return validateKibanaIndexThresholdRule(
+				d.Get("agg_type").(string),
+				d.Get("agg_field").(string),
+				d.Get("group_by").(string),
+				d.Get("term_field").(string),
+				d.Get("term_size").(int),
+				d.Get("threshold_comparator").(string),
+				len(d.Get("threshold").([]interface{})),
+			)
+		},
+	})
+}
+
+// validateKibanaIndexThresholdRule permit to check params that depend on other params, like Kibana do on create
+func validateKibanaIndexThresholdRule(aggType string, aggField string, groupBy string, termField string, termSize int, comparator string, nbThresholds int) error {
+	if aggType != "count" && aggField == "" {
+		return fmt.Errorf("agg_field is required when agg_type is %s", aggType)
+	}
+	if groupBy == "top" && (termField == "" || termSize == 0) {
+		return fmt.Errorf("term_field and term_size are required when group_by is top")
+	}
+	switch comparator {
+	case "between", "notBetween":
+		if nbThresholds != 2 {
+			return fmt.Errorf("threshold must have 2 values when threshold_comparator is %s", comparator)
+		}
+	default:
+		if nbThresholds != 1 {
+			return fmt.Errorf("threshold must have 1 value when threshold_comparator is %s", comparator)
+		}
+	}
+
+	return nil
+}
+
+// buildKibanaIndexThresholdRuleParams permit to build the rule params from resource
+func buildKibanaIndexThresholdRuleParams(d *schema.ResourceData) (map[string]any, error) {
+	thresholds := make([]float64, 0, 2)
+	for _, threshold := range d.Get("threshold").([]interface{}) {
+		thresholds = append(thresholds, threshold.(float64))
+	}
+
+	params := map[string]any{
+		"index":               convertArrayInterfaceToArrayString(d.Get("index").([]interface{})),
+		"timeField":           d.Get("time_field").(string),
+		"aggType":             d.Get("agg_type").(string),
+		"groupBy":             d.Get("group_by").(string),
+		"timeWindowSize":      d.Get("time_window_size").(int),
+		"timeWindowUnit":      d.Get("time_window_unit").(string),
+		"thresholdComparator": d.Get("threshold_comparator").(string),
+		"threshold":           thresholds,
+	}
+	if aggField := d.Get("agg_field").(string); aggField != "" {
+		params["aggField"] = aggField
+	}
+	if termField := d.Get("term_field").(string); termField != "" {
+		params["termField"] = termField
+	}
+	if termSize := d.Get("term_size").(int); termSize > 0 {
+		params["termSize"] = termSize
+	}
+	if filterKuery := d.Get("filter_kuery").(string); filterKuery != "" {
+		params["filterKuery"] = filterKuery
+	}
+
+	return params, nil
+}
+
+// flattenKibanaIndexThresholdRuleParams permit to set the rule params on resource
+func flattenKibanaIndexThresholdRuleParams(d *schema.ResourceData, params map[string]any) error {
+	var err error
+
+	termSize, _ := params["termSize"].(float64)
+	timeWindowSize, _ := params["timeWindowSize"].(float64)
+
+	if err = d.Set("index", params["index"]); err != nil {
+		return err
+	}
+	if err = d.Set("time_field", params["timeField"]); err != nil {
+		return err
+	}
+	if err = d.Set("agg_type", params["aggType"]); err != nil {
+		return err
+	}
+	if err = d.Set("agg_field", params["aggField"]); err != nil {
+		return err
+	}
+	if err = d.Set("group_by", params["groupBy"]); err != nil {
+		return err
+	}
+	if err = d.Set("term_field", params["termField"]); err != nil {
+		return err
+	}
+	if err = d.Set("term_size", int(termSize)); err != nil {
+		return err
+	}
+	if err = d.Set("time_window_size", int(timeWindowSize)); err != nil {
+		return err
+	}
+	if err = d.Set("time_window_unit", params["timeWindowUnit"]); err != nil {
+		return err
+	}
+	if err = d.Set("threshold_comparator", params["thresholdComparator"]); err != nil {
+		return err
+	}
+	if err = d.Set("threshold", params["threshold"]); err != nil {
+		return err
+	}
+	if err = d.Set("filter_kuery", params["filterKuery"]); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccKibanaIndexThresholdRule(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTypedRuleDestroy("kibana_index_threshold_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaIndexThresholdRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_index_threshold_rule.test", "rule_id"),
+					resource.TestCheckResourceAttrSet("kibana_index_threshold_rule.test", "kibana_url"),
+					resource.TestCheckResourceAttr("kibana_index_threshold_rule.test", "revision", "0"),
+					resource.TestCheckResourceAttr("kibana_index_threshold_rule.test", "threshold.#", "1"),
+				),
+			},
+			{
+				Config: testKibanaIndexThresholdRuleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_index_threshold_rule.test", "agg_type", "avg"),
+					resource.TestCheckResourceAttr("kibana_index_threshold_rule.test", "term_size", "5"),
+					resource.TestCheckResourceAttr("kibana_index_threshold_rule.test", "threshold.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "kibana_index_threshold_rule.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"merge_params"},
+			},
+		},
+	})
+}
+
+func TestValidateKibanaIndexThresholdRule(t *testing.T) {
+	if err := validateKibanaIndexThresholdRule("count", "", "all", "", 0, ">", 1); err != nil {
+		t.Errorf("Expected valid count rule, got %s", err)
+	}
+	if err := validateKibanaIndexThresholdRule("avg", "", "all", "", 0, ">", 1); err == nil {
+		t.Error("Expected error when agg_field is missing")
+	}
+	if err := validateKibanaIndexThresholdRule("count", "", "top", "host.name", 0, ">", 1); err == nil {
+		t.Error("Expected error when term_size is missing")
+	}
+	if err := validateKibanaIndexThresholdRule("count", "", "all", "", 0, "between", 1); err == nil {
+		t.Error("Expected error when between has only one threshold")
+	}
+	if err := validateKibanaIndexThresholdRule("count", "", "all", "", 0, "<", 2); err == nil {
+		t.Error("Expected error when < has two thresholds")
+	}
+}
+
+func TestBuildKibanaIndexThresholdRuleParams(t *testing.T) {
+	resource := resourceKibanaIndexThresholdRule()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":                 "test",
+		"index":                []interface{}{"metrics-*"},
+		"time_field":           "@timestamp",
+		"agg_type":             "max",
+		"agg_field":            "system.cpu.total.norm.pct",
+		"group_by":             "top",
+		"term_field":           "host.name",
+		"term_size":            10,
+		"threshold_comparator": "between",
+		"threshold":            []interface{}{0.8, 0.95},
+	})
+
+	params, err := buildKibanaIndexThresholdRuleParams(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["aggField"] != "system.cpu.total.norm.pct" || params["termSize"] != 10 {
+		t.Errorf("Unexpected aggregation params: %+v", params)
+	}
+	if thresholds := params["threshold"].([]float64); len(thresholds) != 2 || thresholds[1] != 0.95 {
+		t.Errorf("Expected 2 thresholds, got %+v", params["threshold"])
+	}
+	if _, ok := params["filterKuery"]; ok {
+		t.Error("Expected no filterKuery when not set")
+	}
+}
+
+var testKibanaIndexThresholdRule = `
+resource kibana_index_threshold_rule "test" {
+  name                 = "terraform-test"
+  index                = ["terraform-test"]
+  time_field           = "@timestamp"
+  threshold_comparator = ">"
+  threshold            = [100]
+}
+`
+
+var testKibanaIndexThresholdRuleUpdate = `
+resource kibana_index_threshold_rule "test" {
+  name                 = "terraform-test"
+  merge_params         = true
+  index                = ["terraform-test"]
+  time_field           = "@timestamp"
+  agg_type             = "avg"
+  agg_field            = "duration"
+  group_by             = "top"
+  term_field           = "host.name"
+  term_size            = 5
+  threshold_comparator = "between"
+  threshold            = [100, 200]
+  filter_kuery         = "event.outcome : failure"
+}
+`
@@ -0,0 +1,200 @@
+// Link dashboard to asset details view of Infrastructure
+// API documentation: not documented, API used by Kibana Infrastructure UI
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource specification to handle custom dashboard of asset details view
+func resourceKibanaInfraCustomDashboard() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaInfraCustomDashboardCreate,
+		ReadContext:   resourceKibanaInfraCustomDashboardRead,
+		UpdateContext: resourceKibanaInfraCustomDashboardUpdate,
+		DeleteContext: resourceKibanaInfraCustomDashboardDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"asset_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "host",
+				ValidateFunc: validation.StringInSlice([]string{"host", "container"}, false),
+			},
+			"dashboard_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"filter_by_asset_id": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"custom_dashboard_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Link dashboard to asset details view
+func resourceKibanaInfraCustomDashboardCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+	assetType := d.Get("asset_type").(string)
+
+	client := meta.(*kibanaMeta).client
+
+	customDashboard, err := createKibanaInfraCustomDashboard(client.Client, space, assetType, buildKibanaInfraCustomDashboard(d))
+	if err != nil {
+		return handleAPIError(err, "create custom dashboard")
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", space, assetType, customDashboard.ID))
+
+	log.Infof("Created custom dashboard %s successfully", d.Id())
+	fmt.Printf("[INFO] Created custom dashboard %s successfully", d.Id())
+
+	return resourceKibanaInfraCustomDashboardRead(ctx, d, meta)
+}
+
+// Read existing custom dashboard
+func resourceKibanaInfraCustomDashboardRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var err error
+	id := d.Id()
+
+	log.Debugf("Custom dashboard id: %s", id)
+
+	space, assetType, customDashboardID, err := parseKibanaInfraCustomDashboardID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	customDashboard, err := getKibanaInfraCustomDashboard(client.Client, space, assetType, customDashboardID)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("read custom dashboard %s", d.Id()))
+	}
+
+	if customDashboard == nil {
+		log.Warnf("Custom dashboard %s not found - removing from state", id)
+		fmt.Printf("[WARN] Custom dashboard %s not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	log.Debugf("Get custom dashboard %s successfully:\n%+v", id, customDashboard)
+
+	if err = d.Set("space", space); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("asset_type", assetType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("dashboard_id", customDashboard.DashboardSavedObjectID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("filter_by_asset_id", customDashboard.DashboardFilterAssetByID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("custom_dashboard_id", customDashboard.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read custom dashboard %s successfully", id)
+	fmt.Printf("[INFO] Read custom dashboard %s successfully", id)
+
+	return nil
+}
+
+// Update existing custom dashboard
+func resourceKibanaInfraCustomDashboardUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	space, assetType, customDashboardID, err := parseKibanaInfraCustomDashboardID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	customDashboard := buildKibanaInfraCustomDashboard(d)
+	customDashboard.ID = customDashboardID
+	if _, err = updateKibanaInfraCustomDashboard(client.Client, space, assetType, customDashboard); err != nil {
+		return handleAPIError(err, fmt.Sprintf("update custom dashboard %s", d.Id()))
+	}
+
+	log.Infof("Updated custom dashboard %s successfully", id)
+	fmt.Printf("[INFO] Updated custom dashboard %s successfully", id)
+
+	return resourceKibanaInfraCustomDashboardRead(ctx, d, meta)
+}
+
+// Delete existing custom dashboard
+func resourceKibanaInfraCustomDashboardDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+	log.Debugf("Custom dashboard id: %s", id)
+
+	space, assetType, customDashboardID, err := parseKibanaInfraCustomDashboardID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if err = deleteKibanaInfraCustomDashboard(client.Client, space, assetType, customDashboardID); err != nil {
+		if isAPIErrorNotFound(err) {
+			log.Warnf("Custom dashboard %s not found - removing from state", id)
+			fmt.Printf("[WARN] Custom dashboard %s not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return handleAPIError(err, fmt.Sprintf("delete custom dashboard %s", id))
+	}
+
+	d.SetId("")
+
+	log.Infof("Deleted custom dashboard %s successfully", id)
+	fmt.Printf("[INFO] Deleted custom dashboard %s successfully", id)
+	return nil
+}
+
+// buildKibanaInfraCustomDashboard permit to build custom dashboard from resource
+func buildKibanaInfraCustomDashboard(d *schema.ResourceData) *kibanaInfraCustomDashboard {
+	return &kibanaInfraCustomDashboard{
+		DashboardSavedObjectID:   d.Get("dashboard_id").(string),
+		DashboardFilterAssetByID: d.Get("filter_by_asset_id").(bool),
+	}
+}
+
+// parseKibanaInfraCustomDashboardID permit to extract space, asset type and custom dashboard ID from resource ID
+func parseKibanaInfraCustomDashboardID(id string) (space string, assetType string, customDashboardID string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("Custom dashboard ID must be <space>/<asset_type>/<custom_dashboard_id>, got %s", id)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
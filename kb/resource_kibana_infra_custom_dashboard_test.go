@@ -0,0 +1,100 @@
+package kb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/pkg/errors"
+)
+
+func TestAccKibanaInfraCustomDashboard(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaInfraCustomDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaInfraCustomDashboard,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckKibanaInfraCustomDashboardExists("kibana_infra_custom_dashboard.test"),
+				),
+			},
+			{
+				ResourceName:      "kibana_infra_custom_dashboard.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckKibanaInfraCustomDashboardExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No custom dashboard ID is set")
+		}
+
+		space, assetType, id, err := parseKibanaInfraCustomDashboardID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		customDashboard, err := getKibanaInfraCustomDashboard(client.Client, space, assetType, id)
+		if err != nil {
+			return err
+		}
+		if customDashboard == nil {
+			return errors.Errorf("Custom dashboard %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckKibanaInfraCustomDashboardDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kibana_infra_custom_dashboard" {
+			continue
+		}
+
+		space, assetType, id, err := parseKibanaInfraCustomDashboardID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		customDashboard, err := getKibanaInfraCustomDashboard(client.Client, space, assetType, id)
+		if err != nil {
+			return err
+		}
+		if customDashboard == nil {
+			return nil
+		}
+
+		return fmt.Errorf("Custom dashboard %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testKibanaInfraCustomDashboard = `
+resource kibana_infra_custom_dashboard "test" {
+  asset_type         = "host"
+  dashboard_id       = "terraform-test"
+  filter_by_asset_id = true
+}
+`
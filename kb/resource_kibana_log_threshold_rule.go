@@ -0,0 +1,185 @@
+// Manage log threshold rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/observability/current/logs-threshold-alert.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	kibanaLogThresholdCountComparators    = []string{"more than", "more than or equals", "less than", "less than or equals", "equals", "does not equal"}
+	kibanaLogThresholdCriteriaComparators = []string{"more than", "more than or equals", "less than", "less than or equals", "equals", "does not equal", "matches", "does not match", "matches phrase", "does not match phrase"}
+)
+
+// Resource specification to handle log threshold rule in Kibana
+func resourceKibanaLogThresholdRule() *schema.Resource {
+	return resourceKibanaTypedRule(&kibanaTypedRule{
+		ruleTypeID:   "logs.alert.document.count",
+		consumer:     "logs",
+		description:  "`kibana_log_threshold_rule` manage a log threshold rule, that alert when the number of log entries matching criteria cross the threshold.",
+		actionGroups: []string{"logs.threshold.fired"},
+		paramsSchema: map[string]*schema.Schema{
+			"log_view_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+			},
+			"time_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"time_unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "m",
+				ValidateFunc: validation.StringInSlice([]string{"s", "m", "h", "d"}, false),
+			},
+			"threshold": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"comparator": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(kibanaLogThresholdCountComparators, false),
+						},
+						"value": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"criteria": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"comparator": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(kibanaLogThresholdCriteriaComparators, false),
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"group_by": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		buildParams:   buildKibanaLogThresholdRuleParams,
+		flattenParams: flattenKibanaLogThresholdRuleParams,
+	})
+}
+
+// buildKibanaLogThresholdRuleParams permit to build the rule params from resource
+func buildKibanaLogThresholdRuleParams(d *schema.ResourceData) (map[string]any, error) {
+	threshold := d.Get("threshold").([]interface{})[0].(map[string]interface{})
+
+	criteria := make([]any, 0)
+	for _, raw := range d.Get("criteria").([]interface{}) {
+		m := raw.(map[string]interface{})
+		var value any = m["value"].(string)
+		// Numeric comparators expect number
+		switch m["comparator"].(string) {
+		case "more than", "more than or equals", "less than", "less than or equals":
+			value = parseKibanaRuleValue(m["value"].(string))
+		}
+		criteria = append(criteria, map[string]any{
+			"field":      m["field"].(string),
+			"comparator": m["comparator"].(string),
+			"value":      value,
+		})
+	}
+
+	params := map[string]any{
+		"logView": map[string]any{
+			"logViewId": d.Get("log_view_id").(string),
+			"type":      "log-view-reference",
+		},
+		"timeSize": d.Get("time_size").(int),
+		"timeUnit": d.Get("time_unit").(string),
+		"count": map[string]any{
+			"comparator": threshold["comparator"].(string),
+			"value":      threshold["value"].(int),
+		},
+		"criteria": criteria,
+	}
+	if groupBy := d.Get("group_by").([]interface{}); len(groupBy) > 0 {
+		params["groupBy"] = convertArrayInterfaceToArrayString(groupBy)
+	}
+
+	return params, nil
+}
+
+// flattenKibanaLogThresholdRuleParams permit to set the rule params on resource
+func flattenKibanaLogThresholdRuleParams(d *schema.ResourceData, params map[string]any) error {
+	var err error
+
+	logViewID := "default"
+	if logView, ok := params["logView"].(map[string]any); ok {
+		logViewID, _ = logView["logViewId"].(string)
+	}
+	count, _ := params["count"].(map[string]any)
+	countValue, _ := count["value"].(float64)
+
+	criteria := make([]interface{}, 0)
+	rawCriteria, _ := params["criteria"].([]any)
+	for _, raw := range rawCriteria {
+		m, _ := raw.(map[string]any)
+		criteria = append(criteria, map[string]interface{}{
+			"field":      m["field"],
+			"comparator": m["comparator"],
+			"value":      formatKibanaRuleValue(m["value"]),
+		})
+	}
+
+	if err = d.Set("log_view_id", logViewID); err != nil {
+		return err
+	}
+	if timeSize, ok := params["timeSize"].(float64); ok {
+		if err = d.Set("time_size", int(timeSize)); err != nil {
+			return err
+		}
+	}
+	if err = d.Set("time_unit", params["timeUnit"]); err != nil {
+		return err
+	}
+	if err = d.Set("threshold", []interface{}{
+		map[string]interface{}{
+			"comparator": count["comparator"],
+			"value":      int(countValue),
+		},
+	}); err != nil {
+		return err
+	}
+	if err = d.Set("criteria", criteria); err != nil {
+		return err
+	}
+	if err = d.Set("group_by", params["groupBy"]); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package kb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccKibanaLogThresholdRule(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTypedRuleDestroy("kibana_log_threshold_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaLogThresholdRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_log_threshold_rule.test", "rule_id"),
+					resource.TestCheckResourceAttr("kibana_log_threshold_rule.test", "criteria.#", "2"),
+					resource.TestCheckResourceAttr("kibana_log_threshold_rule.test", "criteria.1.value", "500"),
+				),
+			},
+			{
+				Config: testKibanaLogThresholdRuleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_log_threshold_rule.test", "threshold.0.value", "100"),
+					resource.TestCheckResourceAttr("kibana_log_threshold_rule.test", "enabled", "false"),
+				),
+			},
+			{
+				// Disabled rule stay disabled when enabled is not set, like after import
+				Config: strings.Replace(testKibanaLogThresholdRuleUpdate, "  enabled = false\n", "", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_log_threshold_rule.test", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "kibana_log_threshold_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestKibanaLogThresholdRuleParams(t *testing.T) {
+	resource := resourceKibanaLogThresholdRule()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "test",
+		"threshold": []interface{}{
+			map[string]interface{}{
+				"comparator": "more than",
+				"value":      10,
+			},
+		},
+		"criteria": []interface{}{
+			map[string]interface{}{
+				"field":      "log.level",
+				"comparator": "equals",
+				"value":      "error",
+			},
+			map[string]interface{}{
+				"field":      "http.response.status_code",
+				"comparator": "more than or equals",
+				"value":      "500",
+			},
+		},
+		"group_by": []interface{}{"host.name"},
+	})
+
+	params, err := buildKibanaLogThresholdRuleParams(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	criteria := params["criteria"].([]any)
+	if criteria[0].(map[string]any)["value"] != "error" || criteria[1].(map[string]any)["value"] != float64(500) {
+		t.Errorf("Expected string and number criteria values, got %+v", criteria)
+	}
+
+	// Kibana return params decoded from JSON
+	apiParams := map[string]any{
+		"logView":  map[string]any{"logViewId": "default", "type": "log-view-reference"},
+		"timeSize": float64(15),
+		"timeUnit": "m",
+		"count":    map[string]any{"comparator": "less than", "value": float64(3)},
+		"criteria": []any{map[string]any{"field": "http.response.status_code", "comparator": "equals", "value": float64(404)}},
+		"groupBy":  []any{"service.name"},
+	}
+	if err = flattenKibanaLogThresholdRuleParams(d, apiParams); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("time_size").(int) != 15 || d.Get("threshold.0.comparator").(string) != "less than" || d.Get("threshold.0.value").(int) != 3 {
+		t.Errorf("Unexpected time window or count: %d %s %d", d.Get("time_size").(int), d.Get("threshold.0.comparator").(string), d.Get("threshold.0.value").(int))
+	}
+	if d.Get("criteria.0.value").(string) != "404" || d.Get("group_by.0").(string) != "service.name" {
+		t.Errorf("Unexpected criteria or group by: %+v %+v", d.Get("criteria"), d.Get("group_by"))
+	}
+}
+
+var testKibanaLogThresholdRule = `
+resource kibana_log_threshold_rule "test" {
+  name = "terraform-test"
+  tags = ["terraform"]
+
+  threshold {
+    comparator = "more than"
+    value      = 50
+  }
+
+  criteria {
+    field      = "log.level"
+    comparator = "equals"
+    value      = "error"
+  }
+
+  criteria {
+    field      = "http.response.status_code"
+    comparator = "more than or equals"
+    value      = "500"
+  }
+
+  group_by = ["host.name"]
+}
+`
+
+var testKibanaLogThresholdRuleUpdate = `
+resource kibana_log_threshold_rule "test" {
+  name    = "terraform-test"
+  tags    = ["terraform"]
+  enabled = false
+
+  threshold {
+    comparator = "more than"
+    value      = 100
+  }
+
+  criteria {
+    field      = "log.level"
+    comparator = "equals"
+    value      = "error"
+  }
+
+  criteria {
+    field      = "http.response.status_code"
+    comparator = "more than or equals"
+    value      = "500"
+  }
+
+  group_by = ["host.name"]
+}
+`
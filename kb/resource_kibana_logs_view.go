@@ -0,0 +1,275 @@
+// Manage the log views used by Logs UI in Kibana
+// API documentation: not documented, API used by Kibana Logs UI
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+// logViewColumnTypes is the mapping between column type and API column key
+var logViewColumnTypes = map[string]string{
+	"timestamp": "timestampColumn",
+	"message":   "messageColumn",
+	"field":     "fieldColumn",
+}
+
+// Resource specification to handle log view in Kibana
+func resourceKibanaLogsView() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaLogsViewCreate,
+		ReadContext:   resourceKibanaLogsViewRead,
+		UpdateContext: resourceKibanaLogsViewUpdate,
+		DeleteContext: resourceKibanaLogsViewDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"log_view_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "default",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"log_indices": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"log_indices", "data_view_id"},
+			},
+			"data_view_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"log_indices", "data_view_id"},
+			},
+			"columns": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"timestamp", "message", "field"}, false),
+						},
+						"field": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Create new log view in Kibana
+func resourceKibanaLogsViewCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+	id := d.Get("log_view_id").(string)
+
+	client := meta.(*kibanaMeta).client
+
+	if err := putKibanaLogView(client.Client, space, id, buildKibanaLogView(d)); err != nil {
+		return handleAPIError(err, "create log view")
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", space, id))
+
+	log.Infof("Created log view %s successfully", d.Id())
+	fmt.Printf("[INFO] Created log view %s successfully", d.Id())
+
+	return resourceKibanaLogsViewRead(ctx, d, meta)
+}
+
+// Read existing log view in Kibana
+func resourceKibanaLogsViewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var err error
+	id := d.Id()
+
+	log.Debugf("Log view id: %s", id)
+
+	space, logViewID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	logView, err := getKibanaLogView(client.Client, space, logViewID)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("read log view %s", d.Id()))
+	}
+
+	if logView == nil {
+		log.Warnf("Log view %s not found - removing from state", id)
+		fmt.Printf("[WARN] Log view %s not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	log.Debugf("Get log view %s successfully:\n%+v", id, logView)
+
+	if err = d.Set("space", space); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("log_view_id", logViewID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("name", logView.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("description", logView.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("log_indices", logView.LogIndices.IndexName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("data_view_id", logView.LogIndices.DataViewID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("columns", flattenKibanaLogViewColumns(logView.LogColumns)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read log view %s successfully", id)
+	fmt.Printf("[INFO] Read log view %s successfully", id)
+
+	return nil
+}
+
+// Update existing log view in Kibana
+func resourceKibanaLogsViewUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	space, logViewID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if err = putKibanaLogView(client.Client, space, logViewID, buildKibanaLogView(d)); err != nil {
+		return handleAPIError(err, fmt.Sprintf("update log view %s", d.Id()))
+	}
+
+	log.Infof("Updated log view %s successfully", id)
+	fmt.Printf("[INFO] Updated log view %s successfully", id)
+
+	return resourceKibanaLogsViewRead(ctx, d, meta)
+}
+
+// Delete existing log view in Kibana
+// The log view is reset to the default configuration
+func resourceKibanaLogsViewDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+	log.Debugf("Log view id: %s", id)
+
+	space, logViewID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if err = client.API.KibanaSavedObject.Delete(kibanaLogViewSavedObjectType, logViewID, space); err != nil {
+		if isAPIErrorNotFound(err) {
+			log.Warnf("Log view %s not found - removing from state", id)
+			fmt.Printf("[WARN] Log view %s not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return handleAPIError(err, fmt.Sprintf("delete log view %s", id))
+	}
+
+	d.SetId("")
+
+	log.Infof("Deleted log view %s successfully", id)
+	fmt.Printf("[INFO] Deleted log view %s successfully", id)
+	return nil
+}
+
+// buildKibanaLogView permit to build log view from resource
+func buildKibanaLogView(d *schema.ResourceData) *kibanaLogView {
+	logView := &kibanaLogView{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		LogColumns:  make([]map[string]kibanaLogViewColumn, 0),
+	}
+
+	if dataViewID := d.Get("data_view_id").(string); dataViewID != "" {
+		logView.LogIndices = kibanaLogViewIndices{
+			Type:       "data_view",
+			DataViewID: dataViewID,
+		}
+	} else {
+		logView.LogIndices = kibanaLogViewIndices{
+			Type:      "index_name",
+			IndexName: d.Get("log_indices").(string),
+		}
+	}
+
+	for i, raw := range d.Get("columns").([]interface{}) {
+		m := raw.(map[string]interface{})
+		columnType := m["type"].(string)
+		logView.LogColumns = append(logView.LogColumns, map[string]kibanaLogViewColumn{
+			logViewColumnTypes[columnType]: {
+				ID:    fmt.Sprintf("%s-%d", columnType, i),
+				Field: m["field"].(string),
+			},
+		})
+	}
+
+	return logView
+}
+
+func flattenKibanaLogViewColumns(columns []map[string]kibanaLogViewColumn) []interface{} {
+	tfList := make([]interface{}, 0, len(columns))
+
+	for _, column := range columns {
+		for columnType, columnKey := range logViewColumnTypes {
+			if item, ok := column[columnKey]; ok {
+				tfList = append(tfList, map[string]interface{}{
+					"type":  columnType,
+					"field": item.Field,
+				})
+			}
+		}
+	}
+
+	return tfList
+}
+
+// parseSpaceObjectID permit to extract space and object ID from resource ID
+func parseSpaceObjectID(id string) (space string, objectID string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("ID must be <space>/<object_id>, got %s", id)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,111 @@
+package kb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKibanaLogsView(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaLogsViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaLogsView,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckKibanaLogsViewExists("kibana_logs_view.test"),
+					resource.TestCheckResourceAttr("kibana_logs_view.test", "log_indices", "logs-test-*"),
+					resource.TestCheckResourceAttr("kibana_logs_view.test", "columns.#", "3"),
+				),
+			},
+			{
+				ResourceName:      "kibana_logs_view.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckKibanaLogsViewExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No log view ID is set")
+		}
+
+		space, id, err := parseSpaceObjectID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		logView, err := getKibanaLogView(client.Client, space, id)
+		if err != nil {
+			return err
+		}
+		if logView == nil {
+			return fmt.Errorf("Log view %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckKibanaLogsViewDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kibana_logs_view" {
+			continue
+		}
+
+		space, id, err := parseSpaceObjectID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		logView, err := getKibanaLogView(client.Client, space, id)
+		if err != nil {
+			return err
+		}
+		if logView != nil && logView.Name == "Terraform test" {
+			return fmt.Errorf("Log view %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testKibanaLogsView = `
+resource kibana_logs_view "test" {
+  log_view_id = "terraform-test"
+  name        = "Terraform test"
+  description = "Log view managed by Terraform"
+  log_indices = "logs-test-*"
+
+  columns {
+    type = "timestamp"
+  }
+  columns {
+    type = "field"
+    field = "host.name"
+  }
+  columns {
+    type = "message"
+  }
+}
+`
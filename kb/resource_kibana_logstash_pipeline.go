@@ -9,7 +9,6 @@ import (
 	"context"
 	"fmt"
 
-	kibana "github.com/disaster37/go-kibana-rest/v8"
 	kbapi "github.com/disaster37/go-kibana-rest/v8/kbapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -95,7 +94,7 @@ func resourceKibanaLogstashPipelineCreate(ctx context.Context, d *schema.Resourc
 
 	logstashPipeline, err := createOrUpdateLogstashPipeline(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleAPIError(err, "create logstash pipeline")
 	}
 
 	d.SetId(logstashPipeline.ID)
@@ -114,11 +113,11 @@ func resourceKibanaLogstashPipelineRead(ctx context.Context, d *schema.ResourceD
 
 	log.Debugf("Logstash pipeline id:  %s", id)
 
-	client := meta.(*kibana.Client)
+	client := meta.(*kibanaMeta).client
 
 	logstashPiepeline, err := client.API.KibanaLogstashPipeline.Get(id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleAPIError(err, fmt.Sprintf("read logstash pipeline %s", d.Id()))
 	}
 
 	if logstashPiepeline == nil {
@@ -175,7 +174,7 @@ func resourceKibanaLogstashPipelineUpdate(ctx context.Context, d *schema.Resourc
 
 	logstashPipeline, err := createOrUpdateLogstashPipeline(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleAPIError(err, fmt.Sprintf("update logstash pipeline %s", d.Id()))
 	}
 
 	log.Infof("Updated logstash piepeline %s successfully", logstashPipeline.ID)
@@ -190,16 +189,16 @@ func resourceKibanaLogstashPipelineDelete(ctx context.Context, d *schema.Resourc
 	id := d.Id()
 	log.Debugf("Logstash pipeline id: %s", id)
 
-	client := meta.(*kibana.Client)
+	client := meta.(*kibanaMeta).client
 
 	if err := client.API.KibanaLogstashPipeline.Delete(id); err != nil {
-		if err.(kbapi.APIError).Code == 404 {
+		if isAPIErrorNotFound(err) {
 			log.Warnf("Logstash pipeline %s not found - removing from state", id)
 			fmt.Printf("[WARN] Logstash pipeline %s not found - removing from state", id)
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return handleAPIError(err, fmt.Sprintf("delete logstash pipeline %s", id))
 
 	}
 
@@ -218,7 +217,7 @@ func createOrUpdateLogstashPipeline(d *schema.ResourceData, meta interface{}) (*
 	pipeline := d.Get("pipeline").(string)
 	settings := d.Get("settings").(*schema.Set).List()
 
-	client := meta.(*kibana.Client)
+	client := meta.(*kibanaMeta).client
 
 	logstashPipeline := &kbapi.LogstashPipeline{
 		ID:          name,
@@ -0,0 +1,196 @@
+// Manage the metrics source used by Infrastructure UI in Kibana
+// API documentation: not documented, API used by Kibana Infrastructure UI
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource specification to handle metrics source in Kibana
+func resourceKibanaMetricsSource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaMetricsSourceCreate,
+		ReadContext:   resourceKibanaMetricsSourceRead,
+		UpdateContext: resourceKibanaMetricsSourceUpdate,
+		DeleteContext: resourceKibanaMetricsSourceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"source_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "default",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"metric_alias": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"anomaly_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      50,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+		},
+	}
+}
+
+// Create new metrics source in Kibana
+func resourceKibanaMetricsSourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+	id := d.Get("source_id").(string)
+
+	client := meta.(*kibanaMeta).client
+
+	if err := patchKibanaMetricsSource(client.Client, space, id, buildKibanaMetricsSource(d)); err != nil {
+		return handleAPIError(err, "create metrics source")
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", space, id))
+
+	log.Infof("Created metrics source %s successfully", d.Id())
+	fmt.Printf("[INFO] Created metrics source %s successfully", d.Id())
+
+	return resourceKibanaMetricsSourceRead(ctx, d, meta)
+}
+
+// Read existing metrics source in Kibana
+func resourceKibanaMetricsSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var err error
+	id := d.Id()
+
+	log.Debugf("Metrics source id: %s", id)
+
+	space, sourceID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	metricsSource, err := getKibanaMetricsSource(client.Client, space, sourceID)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("read metrics source %s", d.Id()))
+	}
+
+	if metricsSource == nil {
+		log.Warnf("Metrics source %s not found - removing from state", id)
+		fmt.Printf("[WARN] Metrics source %s not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	log.Debugf("Get metrics source %s successfully:\n%+v", id, metricsSource)
+
+	if err = d.Set("space", space); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("source_id", sourceID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("name", metricsSource.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("description", metricsSource.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("metric_alias", metricsSource.MetricAlias); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("anomaly_threshold", metricsSource.AnomalyThreshold); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read metrics source %s successfully", id)
+	fmt.Printf("[INFO] Read metrics source %s successfully", id)
+
+	return nil
+}
+
+// Update existing metrics source in Kibana
+func resourceKibanaMetricsSourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	space, sourceID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if err = patchKibanaMetricsSource(client.Client, space, sourceID, buildKibanaMetricsSource(d)); err != nil {
+		return handleAPIError(err, fmt.Sprintf("update metrics source %s", d.Id()))
+	}
+
+	log.Infof("Updated metrics source %s successfully", id)
+	fmt.Printf("[INFO] Updated metrics source %s successfully", id)
+
+	return resourceKibanaMetricsSourceRead(ctx, d, meta)
+}
+
+// Delete existing metrics source in Kibana
+// The metrics source is reset to the default configuration
+func resourceKibanaMetricsSourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+	log.Debugf("Metrics source id: %s", id)
+
+	space, sourceID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if err = client.API.KibanaSavedObject.Delete(kibanaMetricsSourceSavedObjectType, sourceID, space); err != nil {
+		if isAPIErrorNotFound(err) {
+			log.Warnf("Metrics source %s not found - removing from state", id)
+			fmt.Printf("[WARN] Metrics source %s not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return handleAPIError(err, fmt.Sprintf("delete metrics source %s", id))
+	}
+
+	d.SetId("")
+
+	log.Infof("Deleted metrics source %s successfully", id)
+	fmt.Printf("[INFO] Deleted metrics source %s successfully", id)
+	return nil
+}
+
+// buildKibanaMetricsSource permit to build metrics source from resource
+func buildKibanaMetricsSource(d *schema.ResourceData) *kibanaMetricsSource {
+	return &kibanaMetricsSource{
+		Name:             d.Get("name").(string),
+		Description:      d.Get("description").(string),
+		MetricAlias:      d.Get("metric_alias").(string),
+		AnomalyThreshold: d.Get("anomaly_threshold").(int),
+	}
+}
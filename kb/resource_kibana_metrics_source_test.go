@@ -0,0 +1,68 @@
+package kb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKibanaMetricsSource(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaMetricsSourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaMetricsSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_metrics_source.test", "metric_alias", "metrics-test-*"),
+					resource.TestCheckResourceAttr("kibana_metrics_source.test", "anomaly_threshold", "75"),
+				),
+			},
+			{
+				ResourceName:      "kibana_metrics_source.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckKibanaMetricsSourceDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kibana_metrics_source" {
+			continue
+		}
+
+		space, id, err := parseSpaceObjectID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		metricsSource, err := getKibanaMetricsSource(client.Client, space, id)
+		if err != nil {
+			return err
+		}
+		if metricsSource != nil && metricsSource.MetricAlias == "metrics-test-*" {
+			return fmt.Errorf("Metrics source %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testKibanaMetricsSource = `
+resource kibana_metrics_source "test" {
+  name              = "Terraform test"
+  metric_alias      = "metrics-test-*"
+  anomaly_threshold = 75
+}
+`
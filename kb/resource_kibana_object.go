@@ -7,9 +7,12 @@ package kb
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	kibana "github.com/disaster37/go-kibana-rest/v8"
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	log "github.com/sirupsen/logrus"
@@ -30,10 +33,10 @@ func resourceKibanaObject() *schema.Resource {
 				ForceNew: true,
 			},
 			"space": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "default",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
 			},
 			"data": {
 				Type:             schema.TypeString,
@@ -68,6 +71,18 @@ func resourceKibanaObject() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"managed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"reference_overrides": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -104,11 +119,11 @@ func resourceKibanaObjectRead(ctx context.Context, d *schema.ResourceData, meta
 	log.Debugf("Export Objects: %+v", exportObjects)
 	log.Debugf("Space: %s", space)
 
-	client := meta.(*kibana.Client)
+	client := meta.(*kibanaMeta).client
 
 	data, err := client.API.KibanaSavedObject.Export(exportTypes, exportObjects, deepReference, space)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleAPIError(err, "export objects")
 	}
 
 	if len(data) == 0 {
@@ -190,6 +205,29 @@ func buildExportObjects(raws []interface{}) []map[string]string {
 func importObject(d *schema.ResourceData, meta interface{}) error {
 	data := d.Get("data").(string)
 	space := d.Get("space").(string)
+	managed := d.Get("managed").(bool)
+
+	// Managed objects are read-only on Kibana UI (8.12+)
+	if managed {
+		var err error
+		data, err = setManagedNDJSON(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	// Rewrite references to import the objects in another environment
+	if overrides := convertMapInterfaceToMapString(d.Get("reference_overrides").(map[string]interface{})); len(overrides) > 0 {
+		var err error
+		data, err = rewriteReferencesNDJSON(data, func(referenceType string, referenceID string) (string, error) {
+			return resolveReferenceOverride(client, space, overrides, referenceType, referenceID)
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	log.Debugf("Data to import: %s", data)
 
@@ -198,8 +236,6 @@ func importObject(d *schema.ResourceData, meta interface{}) error {
 		err          error
 	)
 
-	client := meta.(*kibana.Client)
-
 	importedData, err = client.API.KibanaSavedObject.Import([]byte(data), true, space)
 	if err != nil {
 		return err
@@ -209,3 +245,99 @@ func importObject(d *schema.ResourceData, meta interface{}) error {
 
 	return nil
 }
+
+// setManagedNDJSON permit to mark all objects of NDJSON as managed
+func setManagedNDJSON(data string) (string, error) {
+	lines := splitNDJSON(data)
+	for i, line := range lines {
+		object := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &object); err != nil {
+			return "", fmt.Errorf("Error when unmarshal object %s: %w", line, err)
+		}
+
+		// Skip export summary line
+		if _, ok := object["exportedCount"]; ok {
+			continue
+		}
+
+		object["managed"] = true
+		b, err := json.Marshal(object)
+		if err != nil {
+			return "", err
+		}
+		lines[i] = string(b)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// referenceResolver return the new ID of reference. It return the same ID when reference is not overridden
+type referenceResolver func(referenceType string, referenceID string) (string, error)
+
+// rewriteReferencesNDJSON permit to rewrite the references of all objects of NDJSON
+func rewriteReferencesNDJSON(data string, resolve referenceResolver) (string, error) {
+	lines := splitNDJSON(data)
+	for i, line := range lines {
+		object := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &object); err != nil {
+			return "", fmt.Errorf("Error when unmarshal object %s: %w", line, err)
+		}
+
+		references, ok := object["references"].([]any)
+		if !ok {
+			continue
+		}
+		for _, rawReference := range references {
+			reference, ok := rawReference.(map[string]any)
+			if !ok {
+				continue
+			}
+			referenceType, _ := reference["type"].(string)
+			referenceID, _ := reference["id"].(string)
+			newID, err := resolve(referenceType, referenceID)
+			if err != nil {
+				return "", err
+			}
+			reference["id"] = newID
+		}
+
+		b, err := json.Marshal(object)
+		if err != nil {
+			return "", err
+		}
+		lines[i] = string(b)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// resolveReferenceOverride return the new ID of reference from overrides.
+// The override can be the new ID, or title:<title> to look up the object with this title in space
+func resolveReferenceOverride(client *kibana.Client, space string, overrides map[string]string, referenceType string, referenceID string) (string, error) {
+	override, ok := overrides[referenceID]
+	if !ok {
+		return referenceID, nil
+	}
+	if !strings.HasPrefix(override, "title:") {
+		return override, nil
+	}
+
+	title := strings.TrimPrefix(override, "title:")
+	objects, err := findAllSavedObjects(client, referenceType, space, &kbapi.OptionalFindParameters{
+		Search:       fmt.Sprintf("\"%s\"", title),
+		SearchFields: []string{"title"},
+		Fields:       []string{"title"},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, object := range objects {
+		attributes, _ := object["attributes"].(map[string]any)
+		if attributes["title"] == title {
+			log.Debugf("Reference %s %s resolved to %s by title %s", referenceType, referenceID, object["id"], title)
+			return object["id"].(string), nil
+		}
+	}
+
+	return "", fmt.Errorf("No %s with title %s found in space %s to override reference %s", referenceType, title, space, referenceID)
+}
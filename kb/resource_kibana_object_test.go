@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	kibana "github.com/disaster37/go-kibana-rest/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/pkg/errors"
@@ -31,6 +30,58 @@ func TestAccKibanaObject(t *testing.T) {
 	})
 }
 
+func TestSetManagedNDJSON(t *testing.T) {
+	data := `{"id":"test1","type":"dashboard","attributes":{"title":"test1"}}
+
+{"id":"test2","type":"index-pattern","attributes":{"title":"test2"}}
+{"exportedCount":2,"missingRefCount":0,"missingReferences":[]}`
+
+	expected := `{"attributes":{"title":"test1"},"id":"test1","managed":true,"type":"dashboard"}
+{"attributes":{"title":"test2"},"id":"test2","managed":true,"type":"index-pattern"}
+{"exportedCount":2,"missingRefCount":0,"missingReferences":[]}`
+
+	result, err := setManagedNDJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, result)
+	}
+
+	if _, err = setManagedNDJSON("not json"); err == nil {
+		t.Error("Expected error on bad NDJSON")
+	}
+}
+
+func TestRewriteReferencesNDJSON(t *testing.T) {
+	data := `{"id":"dash","type":"dashboard","attributes":{"title":"dash"},"references":[{"id":"staging-logs","name":"panel_0","type":"index-pattern"},{"id":"other","name":"panel_1","type":"visualization"}]}
+{"exportedCount":1,"missingRefCount":0,"missingReferences":[]}`
+
+	expected := `{"attributes":{"title":"dash"},"id":"dash","references":[{"id":"prod-logs","name":"panel_0","type":"index-pattern"},{"id":"other","name":"panel_1","type":"visualization"}],"type":"dashboard"}
+{"exportedCount":1,"missingRefCount":0,"missingReferences":[]}`
+
+	result, err := rewriteReferencesNDJSON(data, func(referenceType string, referenceID string) (string, error) {
+		if referenceType == "index-pattern" && referenceID == "staging-logs" {
+			return "prod-logs", nil
+		}
+		return referenceID, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, result)
+	}
+
+	// Resolution error must be returned
+	_, err = rewriteReferencesNDJSON(data, func(referenceType string, referenceID string) (string, error) {
+		return "", errors.New("not found")
+	})
+	if err == nil {
+		t.Error("Expected error")
+	}
+}
+
 func testCheckKibanaObjectExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -51,7 +102,7 @@ func testCheckKibanaObjectExists(name string) resource.TestCheckFunc {
 
 		meta := testAccProvider.Meta()
 
-		client := meta.(*kibana.Client)
+		client := meta.(*kibanaMeta).client
 		data, err := client.API.KibanaSavedObject.Export(nil, exportObjects, deepReference, space)
 		if err != nil {
 			return err
@@ -0,0 +1,199 @@
+// Manage the observability annotations in Kibana
+// API documentation: https://www.elastic.co/guide/en/observability/current/annotations.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource specification to handle observability annotation in Kibana
+func resourceKibanaObservabilityAnnotation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaObservabilityAnnotationCreate,
+		ReadContext:   resourceKibanaObservabilityAnnotationRead,
+		DeleteContext: resourceKibanaObservabilityAnnotationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"message": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "deployment",
+			},
+			"timestamp": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"service_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"service_environment": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"service_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// Create new annotation in Kibana
+func resourceKibanaObservabilityAnnotationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	timestamp := d.Get("timestamp").(string)
+	if timestamp == "" {
+		timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	annotation := &kibanaAnnotation{
+		Timestamp: timestamp,
+		Message:   d.Get("message").(string),
+		Annotation: kibanaAnnotationType{
+			Type: d.Get("type").(string),
+		},
+		Tags: convertArrayInterfaceToArrayString(d.Get("tags").(*schema.Set).List()),
+	}
+	service := &kibanaAnnotationService{
+		Name:        d.Get("service_name").(string),
+		Environment: d.Get("service_environment").(string),
+		Version:     d.Get("service_version").(string),
+	}
+	if service.Name != "" || service.Environment != "" || service.Version != "" {
+		annotation.Service = service
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	document, err := createKibanaAnnotation(client.Client, annotation)
+	if err != nil {
+		return handleAPIError(err, "create annotation")
+	}
+
+	d.SetId(document.ID)
+
+	log.Infof("Created annotation %s successfully", document.ID)
+	fmt.Printf("[INFO] Created annotation %s successfully", document.ID)
+
+	return resourceKibanaObservabilityAnnotationRead(ctx, d, meta)
+}
+
+// Read existing annotation in Kibana
+func resourceKibanaObservabilityAnnotationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var err error
+	id := d.Id()
+
+	log.Debugf("Annotation id: %s", id)
+
+	client := meta.(*kibanaMeta).client
+
+	document, err := getKibanaAnnotation(client.Client, id)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("read annotation %s", d.Id()))
+	}
+
+	if document == nil {
+		log.Warnf("Annotation %s not found - removing from state", id)
+		fmt.Printf("[WARN] Annotation %s not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	log.Debugf("Get annotation %s successfully:\n%+v", id, document)
+
+	annotation := document.Source
+	if err = d.Set("message", annotation.Message); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("type", annotation.Annotation.Type); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("timestamp", annotation.Timestamp); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("tags", annotation.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if annotation.Service != nil {
+		if err = d.Set("service_name", annotation.Service.Name); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set("service_environment", annotation.Service.Environment); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set("service_version", annotation.Service.Version); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Infof("Read annotation %s successfully", id)
+	fmt.Printf("[INFO] Read annotation %s successfully", id)
+
+	return nil
+}
+
+// Delete existing annotation in Kibana
+func resourceKibanaObservabilityAnnotationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+	log.Debugf("Annotation id: %s", id)
+
+	client := meta.(*kibanaMeta).client
+
+	if err := deleteKibanaAnnotation(client.Client, id); err != nil {
+		if isAPIErrorNotFound(err) {
+			log.Warnf("Annotation %s not found - removing from state", id)
+			fmt.Printf("[WARN] Annotation %s not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return handleAPIError(err, fmt.Sprintf("delete annotation %s", id))
+	}
+
+	d.SetId("")
+
+	log.Infof("Deleted annotation %s successfully", id)
+	fmt.Printf("[INFO] Deleted annotation %s successfully", id)
+	return nil
+}
@@ -0,0 +1,97 @@
+package kb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/pkg/errors"
+)
+
+func TestAccKibanaObservabilityAnnotation(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaObservabilityAnnotationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaObservabilityAnnotation,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckKibanaObservabilityAnnotationExists("kibana_observability_annotation.test"),
+					resource.TestCheckResourceAttrSet("kibana_observability_annotation.test", "timestamp"),
+				),
+			},
+			{
+				ResourceName:            "kibana_observability_annotation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"triggers"},
+			},
+		},
+	})
+}
+
+func testCheckKibanaObservabilityAnnotationExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No annotation ID is set")
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		annotation, err := getKibanaAnnotation(client.Client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if annotation == nil {
+			return errors.Errorf("Annotation %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testCheckKibanaObservabilityAnnotationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kibana_observability_annotation" {
+			continue
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		annotation, err := getKibanaAnnotation(client.Client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if annotation == nil {
+			return nil
+		}
+
+		return fmt.Errorf("Annotation %q still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+var testKibanaObservabilityAnnotation = `
+resource kibana_observability_annotation "test" {
+  message             = "Deploy terraform-test 1.0.0"
+  tags                = ["terraform"]
+  service_name        = "terraform-test"
+  service_environment = "test"
+  service_version     = "1.0.0"
+  triggers = {
+    version = "1.0.0"
+  }
+}
+`
@@ -10,7 +10,6 @@ import (
 	"encoding/json"
 	"fmt"
 
-	kibana "github.com/disaster37/go-kibana-rest/v8"
 	kbapi "github.com/disaster37/go-kibana-rest/v8/kbapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -72,6 +71,45 @@ func resourceKibanaRole() *schema.Resource {
 								},
 							},
 						},
+						"remote_indices": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"clusters": {
+										Type:     schema.TypeSet,
+										Required: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"names": {
+										Type:     schema.TypeSet,
+										Required: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"privileges": {
+										Type:     schema.TypeSet,
+										Required: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"query": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										DiffSuppressFunc: suppressEquivalentJSON,
+									},
+									"field_security": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										DiffSuppressFunc: suppressEquivalentJSON,
+									},
+								},
+							},
+						},
 						"cluster": {
 							Type:     schema.TypeSet,
 							Optional: true,
@@ -79,6 +117,28 @@ func resourceKibanaRole() *schema.Resource {
 								Type: schema.TypeString,
 							},
 						},
+						"remote_cluster": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"clusters": {
+										Type:     schema.TypeSet,
+										Required: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"privileges": {
+										Type:     schema.TypeSet,
+										Required: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
 						"run_as": {
 							Type:     schema.TypeSet,
 							Optional: true,
@@ -147,7 +207,7 @@ func resourceKibanaRoleCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	err := createRole(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleAPIError(err, "create role")
 	}
 
 	d.SetId(name)
@@ -166,11 +226,11 @@ func resourceKibanaRoleRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	log.Debugf("Role id:  %s", id)
 
-	client := meta.(*kibana.Client)
+	client := meta.(*kibanaMeta).client
 
-	role, err := client.API.KibanaRoleManagement.Get(id)
+	role, err := getKibanaRole(client.Client, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleAPIError(err, fmt.Sprintf("read role %s", d.Id()))
 	}
 
 	if role == nil {
@@ -180,7 +240,7 @@ func resourceKibanaRoleRead(ctx context.Context, d *schema.ResourceData, meta in
 		return nil
 	}
 
-	log.Debugf("Get role %s successfully:\n%s", id, role)
+	log.Debugf("Get role %s successfully:\n%+v", id, role)
 
 	if err = d.Set("name", id); err != nil {
 		return diag.FromErr(err)
@@ -220,7 +280,7 @@ func resourceKibanaRoleUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	err := createRole(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleAPIError(err, fmt.Sprintf("update role %s", d.Id()))
 	}
 
 	log.Infof("Updated role %s successfully", id)
@@ -235,17 +295,17 @@ func resourceKibanaRoleDelete(ctx context.Context, d *schema.ResourceData, meta
 	id := d.Id()
 	log.Debugf("Role id: %s", id)
 
-	client := meta.(*kibana.Client)
+	client := meta.(*kibanaMeta).client
 
 	err := client.API.KibanaRoleManagement.Delete(id)
 	if err != nil {
-		if err.(kbapi.APIError).Code == 404 {
+		if isAPIErrorNotFound(err) {
 			log.Warnf("Role %s not found - removing from state", id)
 			fmt.Printf("[WARN] Role %s not found - removing from state", id)
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return handleAPIError(err, fmt.Sprintf("delete role %s", id))
 
 	}
 
@@ -267,7 +327,7 @@ func createRole(d *schema.ResourceData, meta interface{}) error {
 	}
 	roleKibana := buildRolesKibana(d.Get("kibana").(*schema.Set).List())
 
-	client := meta.(*kibana.Client)
+	client := meta.(*kibanaMeta).client
 
 	var metadata map[string]interface{}
 	if metadataTemp != nil {
@@ -278,15 +338,13 @@ func createRole(d *schema.ResourceData, meta interface{}) error {
 	} else {
 		metadata = nil
 	}
-	role := &kbapi.KibanaRole{
-		Name:          name,
+	role := &kibanaRole{
 		Elasticsearch: roleElasticsearch,
 		Kibana:        roleKibana,
 		Metadata:      metadata,
 	}
 
-	_, err = client.API.KibanaRoleManagement.CreateOrUpdate(role)
-	if err != nil {
+	if err = putKibanaRole(client.Client, name, role); err != nil {
 		return err
 	}
 
@@ -294,7 +352,7 @@ func createRole(d *schema.ResourceData, meta interface{}) error {
 }
 
 // buildRolesElasticsearch permit to construct kibanaRoleElasticsearch object
-func buildRolesElasticsearch(raws []interface{}) (*kbapi.KibanaRoleElasticsearch, error) {
+func buildRolesElasticsearch(raws []interface{}) (*kibanaRoleElasticsearch, error) {
 	if len(raws) == 0 {
 		return nil, nil
 	}
@@ -302,7 +360,7 @@ func buildRolesElasticsearch(raws []interface{}) (*kbapi.KibanaRoleElasticsearch
 	// We check only the first, we case use multiple KibanaRoleElasticsearch
 	raw := raws[0].(map[string]interface{})
 
-	kibanaRoleElasticsearch := &kbapi.KibanaRoleElasticsearch{}
+	kibanaRoleElasticsearch := &kibanaRoleElasticsearch{}
 
 	if _, ok := raw["run_as"]; ok {
 		kibanaRoleElasticsearch.RunAs = convertArrayInterfaceToArrayString(raw["run_as"].(*schema.Set).List())
@@ -317,6 +375,16 @@ func buildRolesElasticsearch(raws []interface{}) (*kbapi.KibanaRoleElasticsearch
 		}
 		kibanaRoleElasticsearch.Indices = krei
 	}
+	if _, ok := raw["remote_indices"]; ok {
+		krei, err := buildKibanaRoleRemoteIndices(raw["remote_indices"].(*schema.Set).List())
+		if err != nil {
+			return nil, err
+		}
+		kibanaRoleElasticsearch.RemoteIndices = krei
+	}
+	if _, ok := raw["remote_cluster"]; ok {
+		kibanaRoleElasticsearch.RemoteCluster = buildKibanaRoleRemoteCluster(raw["remote_cluster"].(*schema.Set).List())
+	}
 
 	return kibanaRoleElasticsearch, nil
 
@@ -351,6 +419,38 @@ func buildKibanaRoleElasticsearchIndice(raws []interface{}) ([]kbapi.KibanaRoleE
 	return kibanaRoleElasticsearchIndices, nil
 }
 
+// buildKibanaRoleRemoteIndices permit to build the privileges on indices of remote clusters
+func buildKibanaRoleRemoteIndices(raws []interface{}) ([]kibanaRoleRemoteIndice, error) {
+	indices, err := buildKibanaRoleElasticsearchIndice(raws)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteIndices := make([]kibanaRoleRemoteIndice, len(raws))
+	for i, raw := range raws {
+		remoteIndices[i] = kibanaRoleRemoteIndice{
+			KibanaRoleElasticsearchIndice: indices[i],
+			Clusters:                      convertArrayInterfaceToArrayString(raw.(map[string]interface{})["clusters"].(*schema.Set).List()),
+		}
+	}
+
+	return remoteIndices, nil
+}
+
+// buildKibanaRoleRemoteCluster permit to build the cluster privileges on remote clusters
+func buildKibanaRoleRemoteCluster(raws []interface{}) []kibanaRoleRemoteCluster {
+	remoteCluster := make([]kibanaRoleRemoteCluster, len(raws))
+	for i, raw := range raws {
+		m := raw.(map[string]interface{})
+		remoteCluster[i] = kibanaRoleRemoteCluster{
+			Clusters:   convertArrayInterfaceToArrayString(m["clusters"].(*schema.Set).List()),
+			Privileges: convertArrayInterfaceToArrayString(m["privileges"].(*schema.Set).List()),
+		}
+	}
+
+	return remoteCluster
+}
+
 // buildRolesKibana permit to  build list of KibanaRoleKibana object
 func buildRolesKibana(raws []interface{}) []kbapi.KibanaRoleKibana {
 	kibanaRoleKibanas := make([]kbapi.KibanaRoleKibana, len(raws))
@@ -382,10 +482,10 @@ func buildKibanaRoleKibanaFeatures(raws []interface{}) map[string][]string {
 	return features
 }
 
-func flattenKibanaRoleElasticsearchMappings(kre *kbapi.KibanaRoleElasticsearch) ([]interface{}, error) {
+func flattenKibanaRoleElasticsearchMappings(kre *kibanaRoleElasticsearch) ([]interface{}, error) {
 
 	// Handle empty object
-	if kre == nil || (len(kre.Cluster) == 0 && len(kre.Indices) == 0 && len(kre.RunAs) == 0) {
+	if kre == nil || (len(kre.Cluster) == 0 && len(kre.Indices) == 0 && len(kre.RunAs) == 0 && len(kre.RemoteIndices) == 0 && len(kre.RemoteCluster) == 0) {
 		return nil, nil
 	}
 
@@ -399,7 +499,7 @@ func flattenKibanaRoleElasticsearchMappings(kre *kbapi.KibanaRoleElasticsearch)
 	return tfList, nil
 }
 
-func flattenKibanaRoleElasticsearchMapping(kre *kbapi.KibanaRoleElasticsearch) (map[string]interface{}, error) {
+func flattenKibanaRoleElasticsearchMapping(kre *kibanaRoleElasticsearch) (map[string]interface{}, error) {
 	if kre == nil {
 		return nil, nil
 	}
@@ -426,6 +526,26 @@ func flattenKibanaRoleElasticsearchMapping(kre *kbapi.KibanaRoleElasticsearch) (
 		tfMap["run_as"] = make([]interface{}, 0)
 	}
 
+	remoteIndices := make([]interface{}, 0, len(kre.RemoteIndices))
+	for _, item := range kre.RemoteIndices {
+		flatten, err := flattenKibanaRoleElasticsearchMappingIndices(item.KibanaRoleElasticsearchIndice)
+		if err != nil {
+			return nil, err
+		}
+		flatten["clusters"] = item.Clusters
+		remoteIndices = append(remoteIndices, flatten)
+	}
+	tfMap["remote_indices"] = remoteIndices
+
+	remoteCluster := make([]interface{}, 0, len(kre.RemoteCluster))
+	for _, item := range kre.RemoteCluster {
+		remoteCluster = append(remoteCluster, map[string]interface{}{
+			"clusters":   item.Clusters,
+			"privileges": item.Privileges,
+		})
+	}
+	tfMap["remote_cluster"] = remoteCluster
+
 	return tfMap, nil
 }
 
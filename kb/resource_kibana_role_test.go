@@ -1,11 +1,12 @@
 package kb
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
-	kibana "github.com/disaster37/go-kibana-rest/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/pkg/errors"
 )
@@ -36,6 +37,53 @@ func TestAccKibanaRole(t *testing.T) {
 
 }
 
+func TestKibanaRoleRemotePrivileges(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKibanaRole().Schema, map[string]interface{}{
+		"name": "ccs",
+		"elasticsearch": []interface{}{
+			map[string]interface{}{
+				"remote_indices": []interface{}{
+					map[string]interface{}{
+						"clusters":   []interface{}{"prod-*"},
+						"names":      []interface{}{"logs-*"},
+						"privileges": []interface{}{"read"},
+					},
+				},
+				"remote_cluster": []interface{}{
+					map[string]interface{}{
+						"clusters":   []interface{}{"prod-*"},
+						"privileges": []interface{}{"monitor_enrich"},
+					},
+				},
+			},
+		},
+	})
+
+	roleElasticsearch, err := buildRolesElasticsearch(d.Get("elasticsearch").(*schema.Set).List())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(roleElasticsearch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"remote_indices":[{"names":["logs-*"],"privileges":["read"],"query":"","clusters":["prod-*"]}],"remote_cluster":[{"clusters":["prod-*"],"privileges":["monitor_enrich"]}]}`
+	if string(b) != expected {
+		t.Errorf("Expected %s, got %s", expected, string(b))
+	}
+
+	flatten, err := flattenKibanaRoleElasticsearchMappings(roleElasticsearch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = d.Set("elasticsearch", flatten); err != nil {
+		t.Fatal(err)
+	}
+	if remoteIndices := d.Get("elasticsearch").(*schema.Set).List()[0].(map[string]interface{})["remote_indices"].(*schema.Set).List(); len(remoteIndices) != 1 {
+		t.Errorf("Expected remote indices on state, got %+v", remoteIndices)
+	}
+}
+
 func testCheckKibanaRoleExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -48,7 +96,7 @@ func testCheckKibanaRoleExists(name string) resource.TestCheckFunc {
 
 		meta := testAccProvider.Meta()
 
-		client := meta.(*kibana.Client)
+		client := meta.(*kibanaMeta).client
 		role, err := client.API.KibanaRoleManagement.Get(rs.Primary.ID)
 		if err != nil {
 			return err
@@ -69,7 +117,7 @@ func testCheckKibanaRoleDestroy(s *terraform.State) error {
 
 		meta := testAccProvider.Meta()
 
-		client := meta.(*kibana.Client)
+		client := meta.(*kibanaMeta).client
 		role, err := client.API.KibanaRoleManagement.Get(rs.Primary.ID)
 		if err != nil {
 			return err
@@ -0,0 +1,237 @@
+// Apply the same disabled features on many user spaces in Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/master/spaces-api.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource specification to apply disabled features on many user spaces in Kibana
+func resourceKibanaSpacesDisabledFeatures() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaSpacesDisabledFeaturesCreate,
+		ReadContext:   resourceKibanaSpacesDisabledFeaturesRead,
+		UpdateContext: resourceKibanaSpacesDisabledFeaturesUpdate,
+		DeleteContext: resourceKibanaSpacesDisabledFeaturesDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"spaces": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"disabled_features": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"exception": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"space": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"disabled_features": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"spaces_updated": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"spaces_skipped": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Apply disabled features on user spaces
+func resourceKibanaSpacesDisabledFeaturesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+
+	if diags := applyKibanaSpacesDisabledFeatures(d, meta); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(name)
+
+	log.Infof("Applied disabled features %s successfully", name)
+	fmt.Printf("[INFO] Applied disabled features %s successfully", name)
+
+	return resourceKibanaSpacesDisabledFeaturesRead(ctx, d, meta)
+}
+
+// Read disabled features
+// The features are set on each user space, so it keep the state as is
+func resourceKibanaSpacesDisabledFeaturesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	if err := d.Set("name", id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Infof("Read disabled features %s successfully", id)
+	fmt.Printf("[INFO] Read disabled features %s successfully", id)
+
+	return nil
+}
+
+// Apply again disabled features on user spaces
+func resourceKibanaSpacesDisabledFeaturesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	if diags := applyKibanaSpacesDisabledFeatures(d, meta); diags.HasError() {
+		return diags
+	}
+
+	log.Infof("Applied again disabled features %s successfully", id)
+	fmt.Printf("[INFO] Applied again disabled features %s successfully", id)
+
+	return resourceKibanaSpacesDisabledFeaturesRead(ctx, d, meta)
+}
+
+// Delete disabled features just remove resource from state, the user spaces are kept as is
+func resourceKibanaSpacesDisabledFeaturesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	d.SetId("")
+
+	log.Infof("Delete disabled features - just removing from state")
+	fmt.Printf("[INFO] Delete disabled features - just removing from state")
+	return nil
+}
+
+// applyKibanaSpacesDisabledFeatures permit to update the user spaces that not have the expected disabled features
+func applyKibanaSpacesDisabledFeatures(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	spaces := convertArrayInterfaceToArrayString(d.Get("spaces").(*schema.Set).List())
+	disabledFeatures := convertArrayInterfaceToArrayString(d.Get("disabled_features").(*schema.Set).List())
+	exceptions := map[string][]string{}
+	for _, raw := range d.Get("exception").([]interface{}) {
+		m := raw.(map[string]interface{})
+		exceptions[m["space"].(string)] = convertArrayInterfaceToArrayString(m["disabled_features"].(*schema.Set).List())
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	userSpaces, err := listKibanaSpaces(client.Client)
+	if err != nil {
+		return handleAPIError(err, "list user spaces")
+	}
+
+	updates, skipped, err := planKibanaSpacesDisabledFeatures(userSpaces, spaces, disabledFeatures, exceptions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	errs := make([]string, 0)
+	for i := range updates {
+		log.Debugf("Set disabled features on user space %s: %s", updates[i].ID, updates[i].DisabledFeatures)
+		if err = updateKibanaSpace(client.Client, &updates[i]); err != nil {
+			errs = append(errs, fmt.Sprintf("User space %s: %s", updates[i].ID, err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to apply disabled features %s on %d user spaces", name, len(errs)),
+			Detail:   strings.Join(errs, "\n"),
+		}}
+	}
+
+	if err = d.Set("spaces_updated", len(updates)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("spaces_skipped", skipped); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// planKibanaSpacesDisabledFeatures return the user spaces to update, with their expected disabled features, and the number of user spaces already up to date.
+// When spaces is empty, all user spaces are managed. The exceptions replace the disabled features of some user spaces
+func planKibanaSpacesDisabledFeatures(userSpaces []kibanaSpace, spaces []string, disabledFeatures []string, exceptions map[string][]string) ([]kibanaSpace, int, error) {
+	existingSpaces := make(map[string]bool, len(userSpaces))
+	for _, userSpace := range userSpaces {
+		existingSpaces[userSpace.ID] = true
+	}
+	managedSpaces := make(map[string]bool, len(spaces))
+	for _, space := range spaces {
+		if !existingSpaces[space] {
+			return nil, 0, fmt.Errorf("user space %s not exist", space)
+		}
+		managedSpaces[space] = true
+	}
+	for space := range exceptions {
+		if !existingSpaces[space] {
+			return nil, 0, fmt.Errorf("user space %s of exception not exist", space)
+		}
+		if len(managedSpaces) > 0 && !managedSpaces[space] {
+			return nil, 0, fmt.Errorf("user space %s of exception is not on spaces", space)
+		}
+	}
+
+	updates := make([]kibanaSpace, 0)
+	skipped := 0
+	for _, userSpace := range userSpaces {
+		if len(managedSpaces) > 0 && !managedSpaces[userSpace.ID] {
+			continue
+		}
+
+		expectedFeatures := disabledFeatures
+		if features, ok := exceptions[userSpace.ID]; ok {
+			expectedFeatures = features
+		}
+		expectedFeatures = append(make([]string, 0, len(expectedFeatures)), expectedFeatures...)
+		sort.Strings(expectedFeatures)
+		currentFeatures := append(make([]string, 0, len(userSpace.DisabledFeatures)), userSpace.DisabledFeatures...)
+		sort.Strings(currentFeatures)
+
+		if strings.Join(expectedFeatures, ",") == strings.Join(currentFeatures, ",") {
+			skipped++
+			continue
+		}
+
+		userSpace.DisabledFeatures = expectedFeatures
+		updates = append(updates, userSpace)
+	}
+
+	return updates, skipped, nil
+}
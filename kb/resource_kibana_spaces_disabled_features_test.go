@@ -0,0 +1,97 @@
+package kb
+
+import (
+	"testing"
+
+	kbapi "github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKibanaSpacesDisabledFeatures(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaSpacesDisabledFeatures,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_spaces_disabled_features.test", "spaces_updated", "2"),
+					resource.TestCheckResourceAttr("kibana_spaces_disabled_features.test", "spaces_skipped", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestPlanKibanaSpacesDisabledFeatures(t *testing.T) {
+	userSpaces := []kibanaSpace{
+		{KibanaSpace: kbapi.KibanaSpace{ID: "default", Name: "Default"}},
+		{KibanaSpace: kbapi.KibanaSpace{ID: "team-a", Name: "Team A", DisabledFeatures: []string{"siem", "ml"}}},
+		{KibanaSpace: kbapi.KibanaSpace{ID: "team-b", Name: "Team B", Color: "#aabbcc"}, ImageURL: "data:image/png;base64,aGVsbG8="},
+	}
+
+	// All spaces
+	updates, skipped, err := planKibanaSpacesDisabledFeatures(userSpaces, nil, []string{"ml", "siem"}, map[string][]string{"default": {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 2 {
+		t.Errorf("Expected 2 user spaces skipped, got %d", skipped)
+	}
+	if len(updates) != 1 || updates[0].ID != "team-b" || updates[0].Color != "#aabbcc" || updates[0].ImageURL == "" || len(updates[0].DisabledFeatures) != 2 {
+		t.Errorf("Expected only team-b updated, got %+v", updates)
+	}
+
+	// Some spaces
+	updates, skipped, err = planKibanaSpacesDisabledFeatures(userSpaces, []string{"team-a"}, []string{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 0 || len(updates) != 1 || updates[0].ID != "team-a" || len(updates[0].DisabledFeatures) != 0 {
+		t.Errorf("Expected team-a updated without disabled features, got %+v", updates)
+	}
+
+	// Unknown space
+	if _, _, err = planKibanaSpacesDisabledFeatures(userSpaces, []string{"team-c"}, []string{}, nil); err == nil {
+		t.Error("Expected error when user space not exist")
+	}
+
+	// Exception not on spaces
+	if _, _, err = planKibanaSpacesDisabledFeatures(userSpaces, []string{"team-a"}, []string{}, map[string][]string{"team-b": {}}); err == nil {
+		t.Error("Expected error when exception is not on spaces")
+	}
+}
+
+var testKibanaSpacesDisabledFeatures = `
+resource kibana_user_space "team_a" {
+  uid  = "terraform-test-features-a"
+  name = "terraform-test-features-a"
+
+  lifecycle {
+    ignore_changes = [disabled_features]
+  }
+}
+
+resource kibana_user_space "team_b" {
+  uid  = "terraform-test-features-b"
+  name = "terraform-test-features-b"
+
+  lifecycle {
+    ignore_changes = [disabled_features]
+  }
+}
+
+resource kibana_spaces_disabled_features "test" {
+  name              = "terraform-test"
+  spaces            = [kibana_user_space.team_a.uid, kibana_user_space.team_b.uid]
+  disabled_features = ["siem", "ml"]
+
+  exception {
+    space             = kibana_user_space.team_b.uid
+    disabled_features = ["ml"]
+  }
+}
+`
@@ -0,0 +1,204 @@
+// Manage synthetics monitor status rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/observability/current/synthetics-settings.html#synthetics-settings-alerting
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Resource specification to handle synthetics monitor status rule in Kibana
+func resourceKibanaSyntheticsMonitorStatusRule() *schema.Resource {
+	paramsSchema := map[string]*schema.Schema{
+		"number_of_checks": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"time_window_size"},
+			ValidateFunc:  validation.IntBetween(1, 100),
+		},
+		"time_window_size": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			ConflictsWith: []string{"number_of_checks"},
+			ValidateFunc:  validation.IntAtLeast(1),
+		},
+		"time_window_unit": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "m",
+			ValidateFunc: validation.StringInSlice([]string{"m", "h", "d"}, false),
+		},
+		"down_threshold": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      3,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"locations_threshold": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"group_by": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "locationId",
+			ValidateFunc: validation.StringInSlice([]string{"locationId", "none"}, false),
+		},
+	}
+	for key, filterSchema := range kibanaSyntheticsFiltersSchema() {
+		paramsSchema[key] = filterSchema
+	}
+
+	return resourceKibanaTypedRule(&kibanaTypedRule{
+		ruleTypeID:    "xpack.synthetics.alerts.monitorStatus",
+		consumer:      "uptime",
+		description:   "`kibana_synthetics_monitor_status_rule` manage a synthetics monitor status rule, that alert when monitors are down.",
+		actionGroups:  []string{"xpack.synthetics.alerts.actionGroups.monitorStatus"},
+		paramsSchema:  paramsSchema,
+		buildParams:   buildKibanaSyntheticsMonitorStatusRuleParams,
+		flattenParams: flattenKibanaSyntheticsMonitorStatusRuleParams,
+	})
+}
+
+// kibanaSyntheticsFiltersSchema return the schema of filters to select monitors, shared by synthetics rules
+func kibanaSyntheticsFiltersSchema() map[string]*schema.Schema {
+	filtersSchema := map[string]*schema.Schema{}
+	for _, key := range []string{"monitor_ids", "locations", "monitor_tags", "projects"} {
+		filtersSchema[key] = &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+	filtersSchema["monitor_types"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice([]string{"browser", "http", "icmp", "tcp"}, false),
+		},
+	}
+	filtersSchema["kql_query"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+
+	return filtersSchema
+}
+
+// buildKibanaSyntheticsFilters permit to add the filters to select monitors on rule params
+func buildKibanaSyntheticsFilters(d *schema.ResourceData, params map[string]any) {
+	params["monitorIds"] = convertArrayInterfaceToArrayString(d.Get("monitor_ids").(*schema.Set).List())
+	params["locations"] = convertArrayInterfaceToArrayString(d.Get("locations").(*schema.Set).List())
+	params["tags"] = convertArrayInterfaceToArrayString(d.Get("monitor_tags").(*schema.Set).List())
+	params["monitorTypes"] = convertArrayInterfaceToArrayString(d.Get("monitor_types").(*schema.Set).List())
+	params["projects"] = convertArrayInterfaceToArrayString(d.Get("projects").(*schema.Set).List())
+	if kqlQuery := d.Get("kql_query").(string); kqlQuery != "" {
+		params["kqlQuery"] = kqlQuery
+	}
+}
+
+// flattenKibanaSyntheticsFilters permit to set the filters to select monitors on resource
+func flattenKibanaSyntheticsFilters(d *schema.ResourceData, params map[string]any) error {
+	var err error
+
+	if err = d.Set("monitor_ids", params["monitorIds"]); err != nil {
+		return err
+	}
+	if err = d.Set("locations", params["locations"]); err != nil {
+		return err
+	}
+	if err = d.Set("monitor_tags", params["tags"]); err != nil {
+		return err
+	}
+	if err = d.Set("monitor_types", params["monitorTypes"]); err != nil {
+		return err
+	}
+	if err = d.Set("projects", params["projects"]); err != nil {
+		return err
+	}
+	if err = d.Set("kql_query", params["kqlQuery"]); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// buildKibanaSyntheticsMonitorStatusRuleParams permit to build the rule params from resource
+func buildKibanaSyntheticsMonitorStatusRuleParams(d *schema.ResourceData) (map[string]any, error) {
+	window := map[string]any{
+		"numberOfChecks": 5,
+	}
+	if timeWindowSize := d.Get("time_window_size").(int); timeWindowSize > 0 {
+		window = map[string]any{
+			"time": map[string]any{
+				"size": timeWindowSize,
+				"unit": d.Get("time_window_unit").(string),
+			},
+		}
+	} else if numberOfChecks := d.Get("number_of_checks").(int); numberOfChecks > 0 {
+		window["numberOfChecks"] = numberOfChecks
+	}
+
+	params := map[string]any{
+		"condition": map[string]any{
+			"window":             window,
+			"downThreshold":      d.Get("down_threshold").(int),
+			"locationsThreshold": d.Get("locations_threshold").(int),
+			"groupBy":            d.Get("group_by").(string),
+		},
+	}
+	buildKibanaSyntheticsFilters(d, params)
+
+	return params, nil
+}
+
+// flattenKibanaSyntheticsMonitorStatusRuleParams permit to set the rule params on resource
+func flattenKibanaSyntheticsMonitorStatusRuleParams(d *schema.ResourceData, params map[string]any) error {
+	var err error
+
+	condition, _ := params["condition"].(map[string]any)
+	window, _ := condition["window"].(map[string]any)
+	numberOfChecks, _ := window["numberOfChecks"].(float64)
+	timeWindow, _ := window["time"].(map[string]any)
+	timeWindowSize, _ := timeWindow["size"].(float64)
+	downThreshold, _ := condition["downThreshold"].(float64)
+	locationsThreshold, _ := condition["locationsThreshold"].(float64)
+
+	if err = d.Set("number_of_checks", int(numberOfChecks)); err != nil {
+		return err
+	}
+	if err = d.Set("time_window_size", int(timeWindowSize)); err != nil {
+		return err
+	}
+	if unit, ok := timeWindow["unit"].(string); ok {
+		if err = d.Set("time_window_unit", unit); err != nil {
+			return err
+		}
+	}
+	if downThreshold > 0 {
+		if err = d.Set("down_threshold", int(downThreshold)); err != nil {
+			return err
+		}
+	}
+	if locationsThreshold > 0 {
+		if err = d.Set("locations_threshold", int(locationsThreshold)); err != nil {
+			return err
+		}
+	}
+	if groupBy, ok := condition["groupBy"].(string); ok {
+		if err = d.Set("group_by", groupBy); err != nil {
+			return err
+		}
+	}
+
+	return flattenKibanaSyntheticsFilters(d, params)
+}
@@ -0,0 +1,99 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccKibanaSyntheticsMonitorStatusRule(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTypedRuleDestroy("kibana_synthetics_monitor_status_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaSyntheticsMonitorStatusRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_synthetics_monitor_status_rule.test", "rule_id"),
+					resource.TestCheckResourceAttr("kibana_synthetics_monitor_status_rule.test", "number_of_checks", "5"),
+				),
+			},
+			{
+				Config: testKibanaSyntheticsMonitorStatusRuleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_synthetics_monitor_status_rule.test", "time_window_size", "10"),
+					resource.TestCheckResourceAttr("kibana_synthetics_monitor_status_rule.test", "monitor_tags.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "kibana_synthetics_monitor_status_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestKibanaSyntheticsMonitorStatusRuleParams(t *testing.T) {
+	resource := resourceKibanaSyntheticsMonitorStatusRule()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":             "test",
+		"time_window_size": 10,
+		"monitor_tags":     []interface{}{"prod"},
+		"kql_query":        "monitor.name : api*",
+	})
+
+	params, err := buildKibanaSyntheticsMonitorStatusRuleParams(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	condition := params["condition"].(map[string]any)
+	timeWindow := condition["window"].(map[string]any)["time"].(map[string]any)
+	if timeWindow["size"] != 10 || timeWindow["unit"] != "m" || condition["downThreshold"] != 3 {
+		t.Errorf("Unexpected condition: %+v", condition)
+	}
+	if tags := params["tags"].([]string); len(tags) != 1 || tags[0] != "prod" || params["kqlQuery"] != "monitor.name : api*" {
+		t.Errorf("Unexpected filters: %+v", params)
+	}
+
+	// Kibana return params decoded from JSON
+	apiParams := map[string]any{
+		"condition": map[string]any{
+			"window":             map[string]any{"numberOfChecks": float64(3)},
+			"downThreshold":      float64(2),
+			"locationsThreshold": float64(1),
+			"groupBy":            "none",
+		},
+		"monitorIds": []any{"api"},
+		"locations":  []any{},
+	}
+	if err = flattenKibanaSyntheticsMonitorStatusRuleParams(d, apiParams); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("number_of_checks").(int) != 3 || d.Get("time_window_size").(int) != 0 || d.Get("down_threshold").(int) != 2 || d.Get("group_by").(string) != "none" {
+		t.Errorf("Unexpected condition: %d %d %d %s", d.Get("number_of_checks").(int), d.Get("time_window_size").(int), d.Get("down_threshold").(int), d.Get("group_by").(string))
+	}
+	if !d.Get("monitor_ids").(*schema.Set).Contains("api") || d.Get("monitor_tags").(*schema.Set).Len() != 0 {
+		t.Errorf("Unexpected filters: %+v %+v", d.Get("monitor_ids"), d.Get("monitor_tags"))
+	}
+}
+
+var testKibanaSyntheticsMonitorStatusRule = `
+resource kibana_synthetics_monitor_status_rule "test" {
+  name = "terraform-test"
+}
+`
+
+var testKibanaSyntheticsMonitorStatusRuleUpdate = `
+resource kibana_synthetics_monitor_status_rule "test" {
+  name             = "terraform-test"
+  time_window_size = 10
+  down_threshold   = 5
+  monitor_tags     = ["terraform"]
+}
+`
@@ -0,0 +1,72 @@
+// Manage synthetics TLS certificate rules in Kibana
+// API documentation: https://www.elastic.co/guide/en/observability/current/synthetics-settings.html#synthetics-settings-alerting
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Resource specification to handle synthetics TLS certificate rule in Kibana
+func resourceKibanaSyntheticsTLSRule() *schema.Resource {
+	paramsSchema := map[string]*schema.Schema{
+		"cert_expiration_threshold": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"cert_age_threshold": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+	}
+	for key, filterSchema := range kibanaSyntheticsFiltersSchema() {
+		paramsSchema[key] = filterSchema
+	}
+
+	return resourceKibanaTypedRule(&kibanaTypedRule{
+		ruleTypeID:    "xpack.synthetics.alerts.tls",
+		consumer:      "uptime",
+		description:   "`kibana_synthetics_tls_rule` manage a synthetics TLS certificate rule, that alert when monitored certificates expire soon or are too old.",
+		actionGroups:  []string{"xpack.synthetics.alerts.actionGroups.tls"},
+		paramsSchema:  paramsSchema,
+		buildParams:   buildKibanaSyntheticsTLSRuleParams,
+		flattenParams: flattenKibanaSyntheticsTLSRuleParams,
+	})
+}
+
+// buildKibanaSyntheticsTLSRuleParams permit to build the rule params from resource.
+// Thresholds not set use the synthetics settings
+func buildKibanaSyntheticsTLSRuleParams(d *schema.ResourceData) (map[string]any, error) {
+	params := map[string]any{}
+	if certExpirationThreshold := d.Get("cert_expiration_threshold").(int); certExpirationThreshold > 0 {
+		params["certExpirationThreshold"] = certExpirationThreshold
+	}
+	if certAgeThreshold := d.Get("cert_age_threshold").(int); certAgeThreshold > 0 {
+		params["certAgeThreshold"] = certAgeThreshold
+	}
+	buildKibanaSyntheticsFilters(d, params)
+
+	return params, nil
+}
+
+// flattenKibanaSyntheticsTLSRuleParams permit to set the rule params on resource
+func flattenKibanaSyntheticsTLSRuleParams(d *schema.ResourceData, params map[string]any) error {
+	var err error
+
+	certExpirationThreshold, _ := params["certExpirationThreshold"].(float64)
+	certAgeThreshold, _ := params["certAgeThreshold"].(float64)
+
+	if err = d.Set("cert_expiration_threshold", int(certExpirationThreshold)); err != nil {
+		return err
+	}
+	if err = d.Set("cert_age_threshold", int(certAgeThreshold)); err != nil {
+		return err
+	}
+
+	return flattenKibanaSyntheticsFilters(d, params)
+}
@@ -0,0 +1,86 @@
+package kb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccKibanaSyntheticsTLSRule(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTypedRuleDestroy("kibana_synthetics_tls_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaSyntheticsTLSRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_synthetics_tls_rule.test", "rule_id"),
+					resource.TestCheckResourceAttr("kibana_synthetics_tls_rule.test", "cert_expiration_threshold", "30"),
+				),
+			},
+			{
+				Config: testKibanaSyntheticsTLSRuleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_synthetics_tls_rule.test", "cert_age_threshold", "365"),
+				),
+			},
+			{
+				ResourceName:      "kibana_synthetics_tls_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestKibanaSyntheticsTLSRuleParams(t *testing.T) {
+	resource := resourceKibanaSyntheticsTLSRule()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":                      "test",
+		"cert_expiration_threshold": 14,
+		"monitor_types":             []interface{}{"http"},
+	})
+
+	params, err := buildKibanaSyntheticsTLSRuleParams(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["certExpirationThreshold"] != 14 {
+		t.Errorf("Expected expiration threshold 14, got %+v", params)
+	}
+	if _, ok := params["certAgeThreshold"]; ok {
+		t.Error("Expected no age threshold when not set")
+	}
+
+	// Kibana return params decoded from JSON
+	apiParams := map[string]any{
+		"certAgeThreshold": float64(730),
+		"monitorTypes":     []any{"tcp"},
+	}
+	if err = flattenKibanaSyntheticsTLSRuleParams(d, apiParams); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("cert_expiration_threshold").(int) != 0 || d.Get("cert_age_threshold").(int) != 730 || !d.Get("monitor_types").(*schema.Set).Contains("tcp") {
+		t.Errorf("Unexpected params: %d %d %+v", d.Get("cert_expiration_threshold").(int), d.Get("cert_age_threshold").(int), d.Get("monitor_types"))
+	}
+}
+
+var testKibanaSyntheticsTLSRule = `
+resource kibana_synthetics_tls_rule "test" {
+  name                      = "terraform-test"
+  cert_expiration_threshold = 30
+}
+`
+
+var testKibanaSyntheticsTLSRuleUpdate = `
+resource kibana_synthetics_tls_rule "test" {
+  name                      = "terraform-test"
+  cert_expiration_threshold = 30
+  cert_age_threshold        = 365
+}
+`
@@ -0,0 +1,243 @@
+// Manage the timelines and timeline templates of Security in Kibana
+// API documentation: https://www.elastic.co/guide/en/security/current/timeline-api-import.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource specification to handle timeline in Kibana
+func resourceKibanaTimeline() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKibanaTimelineCreate,
+		ReadContext:   resourceKibanaTimelineRead,
+		UpdateContext: resourceKibanaTimelineUpdate,
+		DeleteContext: resourceKibanaTimelineDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: defaultSpaceFunc(),
+			},
+			"data": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentTimeline,
+			},
+			"timeline_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"timeline_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"template_timeline_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Import new timeline in Kibana
+func resourceKibanaTimelineCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	space := d.Get("space").(string)
+
+	id, err := importTimeline(d, meta)
+	if err != nil {
+		return handleAPIError(err, "import timeline")
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", space, id))
+
+	log.Infof("Imported timeline %s successfully", d.Id())
+	fmt.Printf("[INFO] Imported timeline %s successfully", d.Id())
+
+	return resourceKibanaTimelineRead(ctx, d, meta)
+}
+
+// Export existing timeline in Kibana
+func resourceKibanaTimelineRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var err error
+	id := d.Id()
+
+	log.Debugf("Timeline id: %s", id)
+
+	space, timelineID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	data, err := exportKibanaTimeline(client.Client, space, timelineID)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("export timeline %s", id))
+	}
+
+	if data == "" {
+		log.Warnf("Timeline %s not found - removing from state", id)
+		fmt.Printf("[WARN] Timeline %s not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	log.Debugf("Export timeline %s successfully:\n%s", id, data)
+
+	timeline := map[string]any{}
+	if err = json.Unmarshal([]byte(data), &timeline); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("space", space); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("data", data); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("timeline_id", timelineID); err != nil {
+		return diag.FromErr(err)
+	}
+	for attribute, field := range map[string]string{"timeline_type": "timelineType", "template_timeline_id": "templateTimelineId", "title": "title"} {
+		value, _ := timeline[field].(string)
+		if err = d.Set(attribute, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Infof("Read timeline %s successfully", id)
+	fmt.Printf("[INFO] Read timeline %s successfully", id)
+
+	return nil
+}
+
+// Update existing timeline in Kibana
+// Kibana can't import timeline that already exist, so it delete it before import it again
+func resourceKibanaTimelineUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	space, timelineID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if err = deleteKibanaTimeline(client.Client, space, timelineID); err != nil && !isAPIErrorNotFound(err) {
+		return handleAPIError(err, fmt.Sprintf("delete timeline %s", id))
+	}
+
+	newID, err := importTimeline(d, meta)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("import timeline %s", id))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", space, newID))
+
+	log.Infof("Updated timeline %s successfully", d.Id())
+	fmt.Printf("[INFO] Updated timeline %s successfully", d.Id())
+
+	return resourceKibanaTimelineRead(ctx, d, meta)
+}
+
+// Delete existing timeline in Kibana
+func resourceKibanaTimelineDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+	log.Debugf("Timeline id: %s", id)
+
+	space, timelineID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if err = deleteKibanaTimeline(client.Client, space, timelineID); err != nil {
+		if isAPIErrorNotFound(err) {
+			log.Warnf("Timeline %s not found - removing from state", id)
+			fmt.Printf("[WARN] Timeline %s not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return handleAPIError(err, fmt.Sprintf("delete timeline %s", id))
+	}
+
+	d.SetId("")
+
+	log.Infof("Deleted timeline %s successfully", id)
+	fmt.Printf("[INFO] Deleted timeline %s successfully", id)
+	return nil
+}
+
+// importTimeline permit to import timeline and return its saved object ID.
+// Timeline template are found by their template ID, because Kibana can change the saved object ID on import
+func importTimeline(d *schema.ResourceData, meta interface{}) (string, error) {
+	space := d.Get("space").(string)
+	data := d.Get("data").(string)
+
+	timeline := map[string]any{}
+	if err := json.Unmarshal([]byte(data), &timeline); err != nil {
+		return "", err
+	}
+	savedObjectID, _ := timeline["savedObjectId"].(string)
+	templateTimelineID, _ := timeline["templateTimelineId"].(string)
+	if savedObjectID == "" && templateTimelineID == "" {
+		return "", errors.New("Timeline must have savedObjectId or templateTimelineId")
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if err := importKibanaTimeline(client.Client, space, data); err != nil {
+		return "", err
+	}
+
+	if templateTimelineID != "" {
+		id, err := getKibanaTimelineByTemplateID(client.Client, space, templateTimelineID)
+		if err != nil {
+			return "", err
+		}
+		if id == "" {
+			return "", errors.Errorf("Timeline template %s not found after import", templateTimelineID)
+		}
+		return id, nil
+	}
+
+	return savedObjectID, nil
+}
+
+// suppressEquivalentTimeline permit to compare timeline without the fields set by Kibana
+func suppressEquivalentTimeline(k, old, new string, d *schema.ResourceData) bool {
+	excludeFields := map[string]any{
+		"savedObjectId": nil,
+		"version":       nil,
+		"created":       nil,
+		"createdBy":     nil,
+		"updated":       nil,
+		"updatedBy":     nil,
+	}
+
+	return suppressEquivalentJSONWithExclude(k, old, new, d, excludeFields)
+}
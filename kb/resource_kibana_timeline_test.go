@@ -0,0 +1,94 @@
+package kb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKibanaTimeline(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTimelineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaTimeline,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_timeline.test", "timeline_type", "template"),
+					resource.TestCheckResourceAttr("kibana_timeline.test", "title", "Terraform test"),
+				),
+			},
+			{
+				ResourceName:      "kibana_timeline.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestSuppressEquivalentTimeline(t *testing.T) {
+	old := `{"savedObjectId":"1","version":"WzEsMV0=","title":"test","timelineType":"template","updated":1670000000000}`
+	new := `{"savedObjectId":"2","title":"test","timelineType":"template"}`
+	if !suppressEquivalentTimeline("data", old, new, nil) {
+		t.Error("Expected timelines to be equivalent")
+	}
+
+	new = `{"savedObjectId":"1","title":"other","timelineType":"template"}`
+	if suppressEquivalentTimeline("data", old, new, nil) {
+		t.Error("Expected timelines to be different")
+	}
+}
+
+func testCheckKibanaTimelineDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kibana_timeline" {
+			continue
+		}
+
+		space, id, err := parseSpaceObjectID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		meta := testAccProvider.Meta()
+
+		client := meta.(*kibanaMeta).client
+		data, err := exportKibanaTimeline(client.Client, space, id)
+		if err != nil {
+			return err
+		}
+		if data != "" {
+			return fmt.Errorf("Timeline %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testKibanaTimeline = `
+resource kibana_timeline "test" {
+  data = jsonencode({
+    templateTimelineId      = "terraform-test"
+    templateTimelineVersion = 1
+    timelineType            = "template"
+    title                   = "Terraform test"
+    description             = "Timeline template managed by Terraform"
+    status                  = "active"
+    kqlMode                 = "filter"
+    columns = [
+      { columnHeaderType = "not-filtered", id = "@timestamp" },
+      { columnHeaderType = "not-filtered", id = "host.name" }
+    ]
+    eventNotes     = []
+    globalNotes    = []
+    pinnedEventIds = []
+  })
+}
+`
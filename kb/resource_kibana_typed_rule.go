@@ -0,0 +1,1090 @@
+// Manage alerting rules with typed params in Kibana
+// API documentation: https://www.elastic.co/guide/en/kibana/current/alerting-apis.html
+// Supported version:
+//  - v8
+
+package kb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultKibanaTypedRuleNotifyWhen = "onActionGroupChange" // When actions run, when frequency is not set on rule or on actions
+	kibanaRuleVisibleTimeout         = 30 * time.Second      // Max time to wait rule visible after create
+	kibanaRuleVisibleInterval        = time.Second           // Time to wait between two reads of rule not yet visible
+)
+
+// kibanaRuleParamsVarRegexp match the ${name} variables of action params file
+var kibanaRuleParamsVarRegexp = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
+// kibanaTypedRule describe the rule type managed by a typed rule resource.
+// Typed resources only declare the params schema and how to convert them, the rule lifecycle is shared.
+type kibanaTypedRule struct {
+	ruleTypeID    string
+	consumer      string
+	description   string
+	actionGroups  []string // The first one is the default action group
+	paramsSchema  map[string]*schema.Schema
+	buildParams   func(d *schema.ResourceData) (map[string]any, error)
+	flattenParams func(d *schema.ResourceData, params map[string]any) error
+	customizeDiff schema.CustomizeDiffFunc // Optional, to validate params across fields at plan time
+}
+
+// Resource specification to handle rule of one type in Kibana
+func resourceKibanaTypedRule(typedRule *kibanaTypedRule) *schema.Resource {
+	ruleSchema := map[string]*schema.Schema{
+		"space": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			DefaultFunc: defaultSpaceFunc(),
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"consumer": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  typedRule.consumer,
+		},
+		"tags": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"interval": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "1m",
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[1-9][0-9]*[smhd]$`), "must be a duration like 1m"),
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+		},
+		"notify_when": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"onActionGroupChange", "onActiveAlert", "onThrottleInterval"}, false),
+		},
+		"throttle": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[1-9][0-9]*[smhd]$`), "must be a duration like 1h"),
+		},
+		"action": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"connector_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+						Computed: true,
+					},
+					"connector_name": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"group": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      typedRule.actionGroups[0],
+						ValidateFunc: validation.StringInSlice(append(typedRule.actionGroups, "recovered"), false),
+					},
+					"params": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "{}",
+						ValidateFunc:     validation.StringIsJSON,
+						DiffSuppressFunc: suppressKibanaTypedRuleActionParams,
+					},
+					"params_file": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"params_vars": {
+						Type:     schema.TypeMap,
+						Optional: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"frequency": {
+						Type:     schema.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"summary": {
+									Type:     schema.TypeBool,
+									Optional: true,
+									Default:  false,
+								},
+								"notify_when": {
+									Type:         schema.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringInSlice([]string{"onActionGroupChange", "onActiveAlert", "onThrottleInterval"}, false),
+								},
+								"throttle": {
+									Type:         schema.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[1-9][0-9]*[smhd]$`), "must be a duration like 1h"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"merge_params": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"skip_if_unsupported": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"skipped": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"rule_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"execution_status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"kibana_url": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"revision": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"applied_revision": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"on_manual_edit": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "ignore",
+			ValidateFunc: validation.StringInSlice([]string{"ignore", "warn", "fail"}, false),
+		},
+		"policy_json": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	for key, paramSchema := range typedRule.paramsSchema {
+		ruleSchema[key] = paramSchema
+	}
+
+	return &schema.Resource{
+		Description: typedRule.description,
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return resourceKibanaTypedRuleCreate(ctx, d, meta, typedRule)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			// Kibana increment revision on each update, so on refresh it only advance when rule is edited outside Terraform.
+			// Revision is not on state before import or before upgrade of provider
+			revision := -1
+			if state := d.GetRawState(); !state.IsNull() && !state.GetAttr("revision").IsNull() {
+				revision = d.Get("revision").(int)
+			}
+			diags := resourceKibanaTypedRuleRead(ctx, d, meta, typedRule)
+			if diags.HasError() || d.Id() == "" {
+				return diags
+			}
+			// Applied revision is not on state after import, so the rule is taken as it is
+			if state := d.GetRawState(); state.IsNull() || state.GetAttr("applied_revision").IsNull() {
+				if err := d.Set("applied_revision", d.Get("revision").(int)); err != nil {
+					return append(diags, diag.FromErr(err)...)
+				}
+			}
+			return append(diags, checkKibanaTypedRuleRevision(d.Get("on_manual_edit").(string), d.Id(), revision, d.Get("revision").(int))...)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return resourceKibanaTypedRuleUpdate(ctx, d, meta, typedRule)
+		},
+		DeleteContext: resourceKibanaTypedRuleDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// Fail on plan and not on refresh, so the user can still reconcile the manual edits
+			if d.Id() != "" && d.Get("on_manual_edit").(string) == "fail" {
+				if err := validateKibanaTypedRuleRevision(d.Id(), d.Get("applied_revision").(int), d.Get("revision").(int)); err != nil {
+					return err
+				}
+			}
+			// Enabled is only managed when it's set, so imported rules keep their state. New rules are enabled by default
+			if d.Id() == "" {
+				if config := d.GetRawConfig(); !config.IsNull() && config.GetAttr("enabled").IsNull() {
+					if err := d.SetNew("enabled", true); err != nil {
+						return err
+					}
+				}
+			}
+			// Frequency of actions and of rule are read from config, because notify_when of rule is computed from them
+			actionsFrequency := false
+			if config := d.GetRawConfig(); !config.IsNull() && config.GetAttr("action").IsKnown() && config.GetAttr("notify_when").IsKnown() && config.GetAttr("throttle").IsKnown() {
+				known := true
+				frequencies := make([]*kibanaAlertingRuleActionFrequency, 0)
+				if !config.GetAttr("action").IsNull() {
+					for it := config.GetAttr("action").ElementIterator(); it.Next(); {
+						_, action := it.Element()
+						if !action.IsKnown() || !action.GetAttr("frequency").IsWhollyKnown() {
+							known = false
+							break
+						}
+						frequencies = append(frequencies, convertKibanaTypedRuleActionFrequencyConfig(action.GetAttr("frequency")))
+					}
+				}
+				if known {
+					hasRuleFrequency := !config.GetAttr("notify_when").IsNull() || !config.GetAttr("throttle").IsNull()
+					if err := validateKibanaTypedRuleActionsFrequency(hasRuleFrequency, frequencies); err != nil {
+						return err
+					}
+					for _, frequency := range frequencies {
+						actionsFrequency = actionsFrequency || frequency != nil
+					}
+				}
+			}
+			// Values from other resources are only known on apply
+			if !actionsFrequency && d.NewValueKnown("notify_when") && d.NewValueKnown("throttle") {
+				notifyWhen := d.Get("notify_when").(string)
+				if notifyWhen == "" {
+					notifyWhen = defaultKibanaTypedRuleNotifyWhen
+				}
+				if err := validateKibanaTypedRuleThrottle(notifyWhen, d.Get("throttle").(string)); err != nil {
+					return err
+				}
+			}
+			if d.NewValueKnown("interval") {
+				if providerMeta, ok := meta.(*kibanaMeta); ok {
+					if err := validateKibanaTypedRuleInterval(d.Get("interval").(string), providerMeta.ruleIntervalMin); err != nil {
+						return err
+					}
+				}
+			}
+			// Connector ID is computed when connector is referenced by name, so read them from config.
+			// The SDK support ConflictsWith and ExactlyOneOf only on blocks with MaxItems 1, so the fields of each action are checked here
+			if config := d.GetRawConfig(); !config.IsNull() && config.GetAttr("action").IsKnown() && !config.GetAttr("action").IsNull() {
+				index := 0
+				for it := config.GetAttr("action").ElementIterator(); it.Next(); index++ {
+					_, action := it.Element()
+					if !action.IsKnown() {
+						continue
+					}
+					if err := validateKibanaTypedRuleAction(index, !action.GetAttr("connector_id").IsNull(), !action.GetAttr("connector_name").IsNull(), !action.GetAttr("params").IsNull(), !action.GetAttr("params_file").IsNull()); err != nil {
+						return err
+					}
+				}
+			}
+			// Check params files on plan, to not fail halfway on apply
+			for i, raw := range d.Get("action").([]interface{}) {
+				file := raw.(map[string]interface{})["params_file"].(string)
+				if file == "" || !d.NewValueKnown(fmt.Sprintf("action.%d.params_file", i)) || !d.NewValueKnown(fmt.Sprintf("action.%d.params_vars", i)) {
+					continue
+				}
+				if _, err := renderKibanaRuleParamsFile(file, raw.(map[string]interface{})["params_vars"].(map[string]interface{})); err != nil {
+					return fmt.Errorf("action.%d: %s", i, err.Error())
+				}
+			}
+			if typedRule.customizeDiff != nil {
+				if err := typedRule.customizeDiff(ctx, d, meta); err != nil {
+					return err
+				}
+			}
+
+			return customizeKibanaTypedRulePolicy(d, meta, typedRule)
+		},
+
+		Schema: ruleSchema,
+	}
+}
+
+// Create new rule in Kibana
+func resourceKibanaTypedRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, typedRule *kibanaTypedRule) diag.Diagnostics {
+	space := d.Get("space").(string)
+	name := d.Get("name").(string)
+
+	rule, err := buildKibanaTypedRule(d, typedRule)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	enabled := d.Get("enabled").(bool)
+	rule.RuleTypeID = typedRule.ruleTypeID
+	rule.Consumer = d.Get("consumer").(string)
+	rule.Enabled = &enabled
+	if meta.(*kibanaMeta).serverless {
+		setKibanaTypedRuleActionsFrequency(rule)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	// Not create rule when Kibana or its license not support the rule type, so one module can serve all Kibana
+	if d.Get("skip_if_unsupported").(bool) {
+		reason, err := getKibanaTypedRuleUnsupportedReason(client.Client, space, typedRule.ruleTypeID)
+		if err != nil {
+			return handleAPIError(err, "list rule types")
+		}
+		if reason != "" {
+			d.SetId(kibanaTypedRuleSkippedID(space))
+			if err = d.Set("skipped", true); err != nil {
+				return diag.FromErr(err)
+			}
+
+			log.Warnf("Skip rule %s: %s", name, reason)
+			fmt.Printf("[WARN] Skip rule %s: %s", name, reason)
+
+			return diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Rule %s not created", name),
+					Detail:   fmt.Sprintf("%s. The rule will be created on next apply when it's supported.", reason),
+				},
+			}
+		}
+	}
+
+	if err = setKibanaTypedRuleConnectorIDs(d, rule, func(names []string) (map[string]string, error) {
+		return meta.(*kibanaMeta).connectors.resolve(client.Client, space, names)
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule, err = createKibanaAlertingRule(client.Client, space, rule)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("create rule %s", name))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", space, rule.ID))
+
+	log.Infof("Created rule %s successfully", d.Id())
+	fmt.Printf("[INFO] Created rule %s successfully", d.Id())
+
+	return readKibanaTypedRuleApplied(ctx, d, meta, typedRule)
+}
+
+// Read existing rule in Kibana
+func resourceKibanaTypedRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}, typedRule *kibanaTypedRule) diag.Diagnostics {
+	var err error
+	id := d.Id()
+
+	log.Debugf("Rule id: %s", id)
+
+	space, ruleID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	// Remove skipped rule from state when it's supported, to create it on next apply
+	if d.Get("skipped").(bool) {
+		reason, err := getKibanaTypedRuleUnsupportedReason(client.Client, space, typedRule.ruleTypeID)
+		if err != nil {
+			return handleAPIError(err, "list rule types")
+		}
+		if reason == "" {
+			log.Infof("Rule %s is now supported - removing skipped rule from state", d.Get("name").(string))
+			fmt.Printf("[INFO] Rule %s is now supported - removing skipped rule from state", d.Get("name").(string))
+			d.SetId("")
+		}
+		return nil
+	}
+
+	rule, err := getKibanaAlertingRule(client.Client, space, ruleID)
+	if err != nil {
+		return handleAPIError(err, fmt.Sprintf("read rule %s", id))
+	}
+
+	// Rule can be not yet visible just after create, when saved objects are replicated with delay
+	if rule == nil && d.IsNewResource() {
+		rule, err = waitKibanaAlertingRuleVisible(ctx, client.Client, space, ruleID, kibanaRuleVisibleTimeout, kibanaRuleVisibleInterval)
+		if err != nil {
+			return handleAPIError(err, fmt.Sprintf("read rule %s", id))
+		}
+		if rule == nil {
+			return diag.Errorf("Rule %s is created but still not found after %s", id, kibanaRuleVisibleTimeout)
+		}
+	}
+
+	if rule == nil {
+		log.Warnf("Rule %s not found - removing from state", id)
+		fmt.Printf("[WARN] Rule %s not found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	log.Debugf("Get rule %s successfully:\n%+v", id, rule)
+
+	if rule.RuleTypeID != typedRule.ruleTypeID {
+		return diag.Errorf("Rule %s has type %s, but this resource manage rules of type %s", id, rule.RuleTypeID, typedRule.ruleTypeID)
+	}
+
+	// Keep connector name from state while it reference the same connector
+	oldActions := d.Get("action").([]interface{})
+	actionsFrequency := isKibanaTypedRuleActionsFrequencyManaged(oldActions, rule, meta.(*kibanaMeta).serverless)
+	actions := make([]interface{}, 0, len(rule.Actions))
+	for i, action := range rule.Actions {
+		params, err := json.Marshal(action.Params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// Keep params file too, Kibana only return the rendered params
+		connectorName := ""
+		paramsFile := ""
+		paramsVars := map[string]interface{}{}
+		frequency := make([]interface{}, 0, 1)
+		if actionsFrequency && action.Frequency != nil {
+			throttle := ""
+			if action.Frequency.Throttle != nil {
+				throttle = *action.Frequency.Throttle
+			}
+			frequency = append(frequency, map[string]interface{}{
+				"summary":     action.Frequency.Summary,
+				"notify_when": action.Frequency.NotifyWhen,
+				"throttle":    throttle,
+			})
+		}
+		if i < len(oldActions) {
+			oldAction := oldActions[i].(map[string]interface{})
+			if oldAction["connector_id"].(string) == "" || oldAction["connector_id"].(string) == action.ID {
+				connectorName = oldAction["connector_name"].(string)
+			}
+			paramsFile = oldAction["params_file"].(string)
+			paramsVars = oldAction["params_vars"].(map[string]interface{})
+		}
+		actions = append(actions, map[string]interface{}{
+			"connector_id":   action.ID,
+			"connector_name": connectorName,
+			"group":          action.Group,
+			"params":         string(params),
+			"params_file":    paramsFile,
+			"params_vars":    paramsVars,
+			"frequency":      frequency,
+		})
+	}
+	// Serverless and recent Kibana return when actions run on each action.
+	// When frequency is not managed on actions, it's the frequency of rule
+	notifyWhen := rule.NotifyWhen
+	throttle := ""
+	if rule.Throttle != nil {
+		throttle = *rule.Throttle
+	}
+	if !actionsFrequency && notifyWhen == "" && len(rule.Actions) > 0 && rule.Actions[0].Frequency != nil {
+		notifyWhen = rule.Actions[0].Frequency.NotifyWhen
+		if rule.Actions[0].Frequency.Throttle != nil {
+			throttle = *rule.Actions[0].Frequency.Throttle
+		}
+	}
+	executionStatus := ""
+	if rule.ExecutionStatus != nil {
+		executionStatus = rule.ExecutionStatus.Status
+	}
+
+	if err = d.Set("space", space); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("rule_id", rule.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("kibana_url", buildKibanaURL(client.Client.HostURL, space, basePathKibanaRuleApp, rule.ID)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("name", rule.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("consumer", rule.Consumer); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("tags", rule.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("interval", rule.Schedule.Interval); err != nil {
+		return diag.FromErr(err)
+	}
+	if rule.Enabled != nil {
+		if err = d.Set("enabled", *rule.Enabled); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err = d.Set("notify_when", notifyWhen); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("throttle", throttle); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("action", actions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("execution_status", executionStatus); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("revision", rule.Revision); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("skipped", false); err != nil {
+		return diag.FromErr(err)
+	}
+	// Not returned by Kibana, so keep config, or default on import
+	if err = d.Set("merge_params", d.Get("merge_params").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("skip_if_unsupported", d.Get("skip_if_unsupported").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+	onManualEdit := d.Get("on_manual_edit").(string)
+	if onManualEdit == "" {
+		onManualEdit = "ignore"
+	}
+	if err = d.Set("on_manual_edit", onManualEdit); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = typedRule.flattenParams(d, rule.Params); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = setKibanaTypedRulePolicy(d, meta, typedRule); err != nil {
+		return handleAPIError(err, fmt.Sprintf("read connectors of rule %s", id))
+	}
+
+	log.Infof("Read rule %s successfully", id)
+	fmt.Printf("[INFO] Read rule %s successfully", id)
+
+	return nil
+}
+
+// Update existing rule in Kibana
+func resourceKibanaTypedRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, typedRule *kibanaTypedRule) diag.Diagnostics {
+	id := d.Id()
+
+	space, ruleID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if d.Get("skipped").(bool) {
+		log.Infof("Rule %s is skipped, nothing to update", id)
+		return nil
+	}
+
+	if d.HasChangesExcept("enabled") {
+		rule, err := buildKibanaTypedRule(d, typedRule)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rule.ID = ruleID
+		if err = setKibanaTypedRuleConnectorIDs(d, rule, func(names []string) (map[string]string, error) {
+			return meta.(*kibanaMeta).connectors.resolve(client.Client, space, names)
+		}); err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Keep the params that Kibana add and not managed by resource
+		if d.Get("merge_params").(bool) {
+			currentRule, err := getKibanaAlertingRule(client.Client, space, ruleID)
+			if err != nil {
+				return handleAPIError(err, fmt.Sprintf("read rule %s", id))
+			}
+			if currentRule != nil {
+				rule.Params = mergeKibanaRuleParams(currentRule.Params, rule.Params)
+			}
+		}
+
+		if meta.(*kibanaMeta).serverless {
+			setKibanaTypedRuleActionsFrequency(rule)
+		}
+		if err = updateKibanaAlertingRule(client.Client, space, rule); err != nil {
+			return handleAPIError(err, fmt.Sprintf("update rule %s", id))
+		}
+	}
+
+	if d.HasChange("enabled") {
+		if err = enableKibanaAlertingRule(client.Client, space, ruleID, d.Get("enabled").(bool)); err != nil {
+			return handleAPIError(err, fmt.Sprintf("update rule %s", id))
+		}
+	}
+
+	log.Infof("Updated rule %s successfully", id)
+	fmt.Printf("[INFO] Updated rule %s successfully", id)
+
+	return readKibanaTypedRuleApplied(ctx, d, meta, typedRule)
+}
+
+// kibanaTypedRuleSkippedID return the ID of rule not created because it's not supported.
+// It's unique, so many skipped rules of the same space not collide on state
+func kibanaTypedRuleSkippedID(space string) string {
+	return fmt.Sprintf("%s/%s", space, resource.PrefixedUniqueId("skipped-"))
+}
+
+// readKibanaTypedRuleApplied permit to read rule after apply, and keep its revision to detect the next manual edits
+func readKibanaTypedRuleApplied(ctx context.Context, d *schema.ResourceData, meta interface{}, typedRule *kibanaTypedRule) diag.Diagnostics {
+	diags := resourceKibanaTypedRuleRead(ctx, d, meta, typedRule)
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+	if err := d.Set("applied_revision", d.Get("revision").(int)); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// Delete existing rule in Kibana
+func resourceKibanaTypedRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+	log.Debugf("Rule id: %s", id)
+
+	space, ruleID, err := parseSpaceObjectID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("skipped").(bool) {
+		d.SetId("")
+		log.Infof("Rule %s is skipped, nothing to delete", id)
+		return nil
+	}
+
+	client := meta.(*kibanaMeta).client
+
+	if err = meta.(*kibanaMeta).ruleDeletes.delete(client.Client, space, ruleID); err != nil {
+		if isAPIErrorNotFound(err) {
+			log.Warnf("Rule %s not found - removing from state", id)
+			fmt.Printf("[WARN] Rule %s not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return handleAPIError(err, fmt.Sprintf("delete rule %s", id))
+	}
+
+	d.SetId("")
+
+	log.Infof("Deleted rule %s successfully", id)
+	fmt.Printf("[INFO] Deleted rule %s successfully", id)
+	return nil
+}
+
+// checkKibanaTypedRuleRevision return warning when rule revision advanced since last read, because it was edited outside Terraform.
+// Previous revision is negative when it's unknown. With on_manual_edit "fail", the error is returned on plan by validateKibanaTypedRuleRevision
+func checkKibanaTypedRuleRevision(onManualEdit string, id string, previous int, current int) diag.Diagnostics {
+	if onManualEdit != "warn" || previous < 0 || current <= previous {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Rule %s edited outside Terraform", id),
+		Detail:   fmt.Sprintf("The rule revision advanced from %d to %d since last apply. Apply will overwrite the manual changes.", previous, current),
+	}}
+}
+
+// validateKibanaTypedRuleRevision return error when rule revision advanced since last apply, because it was edited outside Terraform
+func validateKibanaTypedRuleRevision(id string, applied int, current int) error {
+	if current <= applied {
+		return nil
+	}
+
+	return fmt.Errorf("rule %s was edited outside Terraform: its revision advanced from %d to %d since last apply. "+
+		"To overwrite the manual changes, set on_manual_edit = \"warn\" and apply. "+
+		"To keep them, report them on config, or remove the rule from state and import it again", id, applied, current)
+}
+
+// waitKibanaAlertingRuleVisible permit to read rule until it's found or timeout is over.
+// It return nil rule when it's still not found
+func waitKibanaAlertingRuleVisible(ctx context.Context, c *resty.Client, space string, id string, timeout time.Duration, interval time.Duration) (*kibanaAlertingRule, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		rule, err := getKibanaAlertingRule(c, space, id)
+		if err != nil || rule != nil || !time.Now().Add(interval).Before(deadline) {
+			return rule, err
+		}
+
+		log.Debugf("Rule %s not yet visible, wait %s before read it again", id, interval)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// buildKibanaTypedRule permit to build the updatable part of rule from resource
+func buildKibanaTypedRule(d *schema.ResourceData, typedRule *kibanaTypedRule) (*kibanaAlertingRule, error) {
+	params, err := typedRule.buildParams(d)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &kibanaAlertingRule{
+		Name: d.Get("name").(string),
+		Tags: convertArrayInterfaceToArrayString(d.Get("tags").(*schema.Set).List()),
+		Schedule: kibanaAlertingRuleSchedule{
+			Interval: d.Get("interval").(string),
+		},
+		Params:     params,
+		Actions:    make([]kibanaAlertingRuleAction, 0),
+		NotifyWhen: d.Get("notify_when").(string),
+	}
+	if throttle := d.Get("throttle").(string); throttle != "" {
+		rule.Throttle = &throttle
+	}
+
+	for _, raw := range d.Get("action").([]interface{}) {
+		m := raw.(map[string]interface{})
+		action := kibanaAlertingRuleAction{
+			ID:     m["connector_id"].(string),
+			Group:  m["group"].(string),
+			Params: map[string]any{},
+		}
+		// Params equivalent to default are suppressed from diff, so they can be empty
+		params := m["params"].(string)
+		if file := m["params_file"].(string); file != "" {
+			if params, err = renderKibanaRuleParamsFile(file, m["params_vars"].(map[string]interface{})); err != nil {
+				return nil, err
+			}
+		}
+		if params != "" {
+			if err = json.Unmarshal([]byte(params), &action.Params); err != nil {
+				return nil, err
+			}
+		}
+		if frequencies := m["frequency"].([]interface{}); len(frequencies) > 0 && frequencies[0] != nil {
+			frequency := frequencies[0].(map[string]interface{})
+			action.Frequency = &kibanaAlertingRuleActionFrequency{
+				Summary:    frequency["summary"].(bool),
+				NotifyWhen: frequency["notify_when"].(string),
+			}
+			if throttle := frequency["throttle"].(string); throttle != "" {
+				action.Frequency.Throttle = &throttle
+			}
+		}
+		rule.Actions = append(rule.Actions, action)
+	}
+
+	// Kibana reject notify_when and throttle of rule when actions have their frequency
+	for _, action := range rule.Actions {
+		if action.Frequency != nil {
+			rule.NotifyWhen = ""
+			rule.Throttle = nil
+			return rule, nil
+		}
+	}
+	if rule.NotifyWhen == "" {
+		rule.NotifyWhen = defaultKibanaTypedRuleNotifyWhen
+	}
+
+	return rule, nil
+}
+
+// getKibanaTypedRuleUnsupportedReason return why the rule type can't be created on Kibana, or empty string when it's supported
+func getKibanaTypedRuleUnsupportedReason(c *resty.Client, space string, ruleTypeID string) (string, error) {
+	ruleTypes, err := listKibanaAlertingRuleTypes(c, space)
+	if err != nil {
+		return "", err
+	}
+
+	return kibanaRuleTypeUnsupportedReason(ruleTypes, ruleTypeID), nil
+}
+
+// kibanaRuleTypeUnsupportedReason return why the rule type is not supported, or empty string when it's supported
+func kibanaRuleTypeUnsupportedReason(ruleTypes []kibanaAlertingRuleType, ruleTypeID string) string {
+	for _, ruleType := range ruleTypes {
+		if ruleType.ID != ruleTypeID {
+			continue
+		}
+		if !ruleType.EnabledInLicense {
+			return fmt.Sprintf("Rule type %s need %s license", ruleTypeID, ruleType.MinimumLicenseRequired)
+		}
+		return ""
+	}
+
+	return fmt.Sprintf("Rule type %s is not available on Kibana", ruleTypeID)
+}
+
+// validateKibanaTypedRuleAction permit to check the action reference the connector by ID or by name,
+// and not set params with params_file
+func validateKibanaTypedRuleAction(index int, hasConnectorID bool, hasConnectorName bool, hasParams bool, hasParamsFile bool) error {
+	if hasConnectorID == hasConnectorName {
+		return fmt.Errorf("action.%d: exactly one of connector_id or connector_name must be set", index)
+	}
+	if hasParams && hasParamsFile {
+		return fmt.Errorf("action.%d: params and params_file can't be set together", index)
+	}
+
+	return nil
+}
+
+// setKibanaTypedRuleConnectorIDs permit to set the ID of connectors referenced by name on actions.
+// All names are resolved in one time, so all not found names are reported together
+func setKibanaTypedRuleConnectorIDs(d *schema.ResourceData, rule *kibanaAlertingRule, resolve func(names []string) (map[string]string, error)) error {
+	names := make([]string, 0)
+	for _, raw := range d.Get("action").([]interface{}) {
+		if name := raw.(map[string]interface{})["connector_name"].(string); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	ids, err := resolve(names)
+	if err != nil {
+		return err
+	}
+	for i, raw := range d.Get("action").([]interface{}) {
+		if name := raw.(map[string]interface{})["connector_name"].(string); name != "" {
+			rule.Actions[i].ID = ids[name]
+		}
+	}
+
+	return nil
+}
+
+// mergeKibanaRuleParams permit to set the params managed by resource on the current params of rule
+func mergeKibanaRuleParams(currentParams map[string]any, params map[string]any) map[string]any {
+	mergedParams := make(map[string]any, len(currentParams)+len(params))
+	for key, value := range currentParams {
+		mergedParams[key] = value
+	}
+	for key, value := range params {
+		mergedParams[key] = value
+	}
+
+	return mergedParams
+}
+
+// setKibanaTypedRuleActionsFrequency permit to move notify_when and throttle of rule on each action, because serverless not support them on rule.
+// Nothing is done when actions already have their frequency
+func setKibanaTypedRuleActionsFrequency(rule *kibanaAlertingRule) {
+	if rule.NotifyWhen == "" {
+		return
+	}
+	for i := range rule.Actions {
+		rule.Actions[i].Frequency = &kibanaAlertingRuleActionFrequency{
+			NotifyWhen: rule.NotifyWhen,
+			Throttle:   rule.Throttle,
+		}
+	}
+	rule.NotifyWhen = ""
+	rule.Throttle = nil
+}
+
+// isKibanaTypedRuleActionsFrequencyManaged return true when the frequency of actions is managed on action blocks, and not with notify_when and throttle of rule.
+// On import, it's managed on actions when rule has no notify_when, except on serverless where the frequency of rule is always moved on actions
+func isKibanaTypedRuleActionsFrequencyManaged(oldActions []interface{}, rule *kibanaAlertingRule, serverless bool) bool {
+	if len(oldActions) == 0 {
+		return !serverless && rule.NotifyWhen == "" && len(rule.Actions) > 0 && rule.Actions[0].Frequency != nil
+	}
+	for _, raw := range oldActions {
+		if frequencies, ok := raw.(map[string]interface{})["frequency"].([]interface{}); ok && len(frequencies) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// convertKibanaTypedRuleActionFrequencyConfig return the frequency of action from config, or nil when it's not set
+func convertKibanaTypedRuleActionFrequencyConfig(config cty.Value) *kibanaAlertingRuleActionFrequency {
+	if config.IsNull() || config.LengthInt() == 0 {
+		return nil
+	}
+	raw := config.Index(cty.NumberIntVal(0))
+	frequency := &kibanaAlertingRuleActionFrequency{}
+	if notifyWhen := raw.GetAttr("notify_when"); !notifyWhen.IsNull() {
+		frequency.NotifyWhen = notifyWhen.AsString()
+	}
+	if throttle := raw.GetAttr("throttle"); !throttle.IsNull() {
+		value := throttle.AsString()
+		frequency.Throttle = &value
+	}
+
+	return frequency
+}
+
+// validateKibanaTypedRuleActionsFrequency permit to check the frequency of actions, like Kibana do on create.
+// Frequency of rule can't be set with frequency of actions, and when one action has frequency, all actions need it
+func validateKibanaTypedRuleActionsFrequency(hasRuleFrequency bool, frequencies []*kibanaAlertingRuleActionFrequency) error {
+	nbFrequencies := 0
+	for i, frequency := range frequencies {
+		if frequency == nil {
+			continue
+		}
+		nbFrequencies++
+		throttle := ""
+		if frequency.Throttle != nil {
+			throttle = *frequency.Throttle
+		}
+		if err := validateKibanaTypedRuleThrottle(frequency.NotifyWhen, throttle); err != nil {
+			return fmt.Errorf("action.%d.frequency: %s", i, err.Error())
+		}
+	}
+	if nbFrequencies == 0 {
+		return nil
+	}
+	if hasRuleFrequency {
+		return fmt.Errorf("notify_when and throttle of rule can't be set when actions have frequency, Kibana reject them together")
+	}
+	if nbFrequencies != len(frequencies) {
+		return fmt.Errorf("frequency must be set on all actions when it's set on one of them")
+	}
+
+	return nil
+}
+
+// validateKibanaTypedRuleThrottle permit to check throttle is only set with notify_when onThrottleInterval, like Kibana do on create
+func validateKibanaTypedRuleThrottle(notifyWhen string, throttle string) error {
+	if throttle != "" && notifyWhen != "onThrottleInterval" {
+		return fmt.Errorf("throttle need notify_when to be onThrottleInterval, got %s", notifyWhen)
+	}
+	if throttle == "" && notifyWhen == "onThrottleInterval" {
+		return fmt.Errorf("throttle is required when notify_when is onThrottleInterval")
+	}
+
+	return nil
+}
+
+// parseKibanaRuleInterval permit to convert rule interval, like 30s or 1d, as duration
+func parseKibanaRuleInterval(interval string) (time.Duration, error) {
+	if len(interval) < 2 {
+		return 0, fmt.Errorf("invalid interval %s, must be a duration like 1m", interval)
+	}
+	value, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid interval %s, must be a duration like 1m", interval)
+	}
+
+	switch interval[len(interval)-1] {
+	case 's':
+		return time.Duration(value) * time.Second, nil
+	case 'm':
+		return time.Duration(value) * time.Minute, nil
+	case 'h':
+		return time.Duration(value) * time.Hour, nil
+	case 'd':
+		return time.Duration(value) * 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid interval %s, must be a duration like 1m", interval)
+	}
+}
+
+// validateKibanaTypedRuleInterval permit to reject rules scheduled more frequently than the provider schedule_interval_min.
+// It protect shared clusters from aggressive schedules. No check when intervalMin is 0
+func validateKibanaTypedRuleInterval(interval string, intervalMin time.Duration) error {
+	if intervalMin <= 0 {
+		return nil
+	}
+	duration, err := parseKibanaRuleInterval(interval)
+	if err != nil {
+		return err
+	}
+	if duration < intervalMin {
+		return fmt.Errorf("interval %s is shorter than %s allowed by provider schedule_interval_min", interval, intervalMin)
+	}
+
+	return nil
+}
+
+// renderKibanaRuleParamsFile permit to read action params from JSON file, after replacing the ${name} variables by their value.
+// It keep HCL short for large params. The variables not set are reported, so typo not end on Kibana
+func renderKibanaRuleParamsFile(file string, vars map[string]interface{}) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("can't read params file: %s", err.Error())
+	}
+
+	missingVars := make([]string, 0)
+	params := kibanaRuleParamsVarRegexp.ReplaceAllStringFunc(string(data), func(match string) string {
+		name := kibanaRuleParamsVarRegexp.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok {
+			missingVars = append(missingVars, name)
+			return match
+		}
+		// Value is inside JSON string, so it must be escaped
+		escaped, _ := json.Marshal(value.(string))
+		return string(escaped[1 : len(escaped)-1])
+	})
+	if len(missingVars) > 0 {
+		return "", fmt.Errorf("params file %s use variables not set on params_vars: %s", file, strings.Join(missingVars, ", "))
+	}
+
+	obj := map[string]any{}
+	if err = json.Unmarshal([]byte(params), &obj); err != nil {
+		return "", fmt.Errorf("params file %s is not a JSON object: %s", file, err.Error())
+	}
+
+	return params, nil
+}
+
+// suppressKibanaTypedRuleActionParams permit to compare the action params from Kibana with the params file when it's set
+func suppressKibanaTypedRuleActionParams(k, old, new string, d *schema.ResourceData) bool {
+	prefix := strings.TrimSuffix(k, "params")
+	if file, _ := d.Get(prefix + "params_file").(string); file != "" {
+		params, err := renderKibanaRuleParamsFile(file, d.Get(prefix+"params_vars").(map[string]interface{}))
+		if err != nil {
+			return false
+		}
+		return suppressEquivalentJSON(k, old, params, d)
+	}
+
+	return suppressEquivalentJSON(k, old, new, d)
+}
+
+// formatKibanaRuleValue permit to convert the string or number value of rule params as string
+func formatKibanaRuleValue(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseKibanaRuleValue permit to send the value as number when it's a number, like Kibana UI do
+func parseKibanaRuleValue(value string) any {
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
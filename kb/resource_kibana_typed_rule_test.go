@@ -0,0 +1,462 @@
+package kb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestBuildKibanaTypedRule(t *testing.T) {
+	typedRule := &kibanaTypedRule{
+		ruleTypeID:   "test",
+		consumer:     "alerts",
+		actionGroups: []string{"threshold met"},
+		paramsSchema: map[string]*schema.Schema{},
+		buildParams: func(d *schema.ResourceData) (map[string]any, error) {
+			return map[string]any{"foo": "bar"}, nil
+		},
+	}
+	resource := resourceKibanaTypedRule(typedRule)
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":        "test",
+		"tags":        []interface{}{"a", "b"},
+		"notify_when": "onThrottleInterval",
+		"throttle":    "1h",
+		"action": []interface{}{
+			map[string]interface{}{
+				"connector_id": "slack",
+				"params":       `{"message": "{{alert.id}}"}`,
+			},
+		},
+	})
+
+	rule, err := buildKibanaTypedRule(d, typedRule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Schedule.Interval != "1m" || rule.NotifyWhen != "onThrottleInterval" || rule.Throttle == nil || *rule.Throttle != "1h" {
+		t.Errorf("Unexpected rule settings: %+v", rule)
+	}
+	if rule.Params["foo"] != "bar" {
+		t.Errorf("Expected params from typed rule, got %+v", rule.Params)
+	}
+	if len(rule.Actions) != 1 || rule.Actions[0].Group != "threshold met" || rule.Actions[0].Params["message"] != "{{alert.id}}" {
+		t.Errorf("Expected action on default group, got %+v", rule.Actions)
+	}
+}
+
+func TestBuildKibanaTypedRuleActionsFrequency(t *testing.T) {
+	typedRule := &kibanaTypedRule{
+		ruleTypeID:   "test",
+		consumer:     "alerts",
+		actionGroups: []string{"threshold met"},
+		paramsSchema: map[string]*schema.Schema{},
+		buildParams: func(d *schema.ResourceData) (map[string]any, error) {
+			return map[string]any{}, nil
+		},
+	}
+	resource := resourceKibanaTypedRule(typedRule)
+
+	// Default notify_when of rule when actions have no frequency
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "test",
+		"action": []interface{}{
+			map[string]interface{}{
+				"connector_id": "slack",
+			},
+		},
+	})
+	rule, err := buildKibanaTypedRule(d, typedRule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.NotifyWhen != defaultKibanaTypedRuleNotifyWhen || rule.Actions[0].Frequency != nil {
+		t.Errorf("Expected default notify_when on rule, got %s %+v", rule.NotifyWhen, rule.Actions[0].Frequency)
+	}
+
+	// Frequency on actions
+	d = schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "test",
+		"action": []interface{}{
+			map[string]interface{}{
+				"connector_id": "slack",
+				"frequency": []interface{}{
+					map[string]interface{}{
+						"summary":     true,
+						"notify_when": "onThrottleInterval",
+						"throttle":    "1h",
+					},
+				},
+			},
+		},
+	})
+	rule, err = buildKibanaTypedRule(d, typedRule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.NotifyWhen != "" || rule.Throttle != nil {
+		t.Errorf("Expected no notify_when and throttle on rule, got %s %v", rule.NotifyWhen, rule.Throttle)
+	}
+	frequency := rule.Actions[0].Frequency
+	if frequency == nil || !frequency.Summary || frequency.NotifyWhen != "onThrottleInterval" || frequency.Throttle == nil || *frequency.Throttle != "1h" {
+		t.Errorf("Unexpected frequency of action: %+v", frequency)
+	}
+
+	// Serverless keep frequency from actions
+	setKibanaTypedRuleActionsFrequency(rule)
+	if rule.Actions[0].Frequency != frequency {
+		t.Errorf("Expected frequency of action not changed, got %+v", rule.Actions[0].Frequency)
+	}
+}
+
+func TestValidateKibanaTypedRuleActionsFrequency(t *testing.T) {
+	throttle := "1h"
+	frequency := &kibanaAlertingRuleActionFrequency{NotifyWhen: "onActiveAlert"}
+
+	if err := validateKibanaTypedRuleActionsFrequency(true, []*kibanaAlertingRuleActionFrequency{nil, nil}); err != nil {
+		t.Errorf("Expected frequency of rule without frequency on actions to be valid, got %s", err)
+	}
+	if err := validateKibanaTypedRuleActionsFrequency(false, []*kibanaAlertingRuleActionFrequency{frequency, {NotifyWhen: "onThrottleInterval", Throttle: &throttle}}); err != nil {
+		t.Errorf("Expected frequency on all actions to be valid, got %s", err)
+	}
+	if err := validateKibanaTypedRuleActionsFrequency(true, []*kibanaAlertingRuleActionFrequency{frequency}); err == nil || !strings.Contains(err.Error(), "can't be set when actions have frequency") {
+		t.Errorf("Expected error when frequency on rule and on actions, got %v", err)
+	}
+	if err := validateKibanaTypedRuleActionsFrequency(false, []*kibanaAlertingRuleActionFrequency{frequency, nil}); err == nil || !strings.Contains(err.Error(), "must be set on all actions") {
+		t.Errorf("Expected error when frequency only on some actions, got %v", err)
+	}
+	if err := validateKibanaTypedRuleActionsFrequency(false, []*kibanaAlertingRuleActionFrequency{frequency, {NotifyWhen: "onThrottleInterval"}}); err == nil || !strings.HasPrefix(err.Error(), "action.1.frequency:") {
+		t.Errorf("Expected error on action.1.frequency when onThrottleInterval without throttle, got %v", err)
+	}
+}
+
+func TestIsKibanaTypedRuleActionsFrequencyManaged(t *testing.T) {
+	rule := &kibanaAlertingRule{
+		Actions: []kibanaAlertingRuleAction{
+			{ID: "slack", Frequency: &kibanaAlertingRuleActionFrequency{NotifyWhen: "onActiveAlert"}},
+		},
+	}
+	withFrequency := []interface{}{map[string]interface{}{"frequency": []interface{}{map[string]interface{}{"notify_when": "onActiveAlert"}}}}
+	withoutFrequency := []interface{}{map[string]interface{}{"frequency": []interface{}{}}}
+
+	if !isKibanaTypedRuleActionsFrequencyManaged(withFrequency, rule, true) {
+		t.Error("Expected frequency managed when actions in state have frequency")
+	}
+	if isKibanaTypedRuleActionsFrequencyManaged(withoutFrequency, rule, false) {
+		t.Error("Expected frequency not managed when actions in state have no frequency")
+	}
+	if !isKibanaTypedRuleActionsFrequencyManaged(nil, rule, false) {
+		t.Error("Expected frequency managed on import when rule has frequency only on actions")
+	}
+	if isKibanaTypedRuleActionsFrequencyManaged(nil, rule, true) {
+		t.Error("Expected frequency not managed on import on serverless")
+	}
+	rule.NotifyWhen = "onActiveAlert"
+	if isKibanaTypedRuleActionsFrequencyManaged(nil, rule, false) {
+		t.Error("Expected frequency not managed on import when rule has notify_when")
+	}
+}
+
+func TestRenderKibanaRuleParamsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "params.json")
+	if err := os.WriteFile(file, []byte(`{"message": "${team} alert on {{rule.name}}", "to": ["${email}"]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	params, err := renderKibanaRuleParamsFile(file, map[string]interface{}{"team": `SRE "core"`, "email": "sre@company.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params != `{"message": "SRE \"core\" alert on {{rule.name}}", "to": ["sre@company.com"]}` {
+		t.Errorf("Expected variables replaced and escaped, got %s", params)
+	}
+
+	_, err = renderKibanaRuleParamsFile(file, map[string]interface{}{"team": "SRE"})
+	if err == nil || !strings.Contains(err.Error(), "not set on params_vars: email") {
+		t.Errorf("Expected error on missing variable, got %v", err)
+	}
+
+	if err = os.WriteFile(file, []byte(`["not", "object"]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = renderKibanaRuleParamsFile(file, nil); err == nil {
+		t.Error("Expected error when params file is not a JSON object")
+	}
+	if _, err = renderKibanaRuleParamsFile(filepath.Join(t.TempDir(), "missing.json"), nil); err == nil {
+		t.Error("Expected error when params file not exist")
+	}
+}
+
+func TestValidateKibanaTypedRuleThrottle(t *testing.T) {
+	if err := validateKibanaTypedRuleThrottle("onThrottleInterval", "1h"); err != nil {
+		t.Errorf("Expected throttle with onThrottleInterval to be valid, got %s", err)
+	}
+	if err := validateKibanaTypedRuleThrottle("onActionGroupChange", ""); err != nil {
+		t.Errorf("Expected no throttle with onActionGroupChange to be valid, got %s", err)
+	}
+	if err := validateKibanaTypedRuleThrottle("onActiveAlert", "1h"); err == nil {
+		t.Error("Expected error when throttle without onThrottleInterval")
+	}
+	if err := validateKibanaTypedRuleThrottle("onThrottleInterval", ""); err == nil {
+		t.Error("Expected error when onThrottleInterval without throttle")
+	}
+}
+
+func TestValidateKibanaTypedRuleInterval(t *testing.T) {
+	if duration, err := parseKibanaRuleInterval("2d"); err != nil || duration != 48*time.Hour {
+		t.Errorf("Expected 2d to be 48h, got %s (%v)", duration, err)
+	}
+	if _, err := parseKibanaRuleInterval("1w"); err == nil {
+		t.Error("Expected error on unknown unit")
+	}
+	if err := validateKibanaTypedRuleInterval("10s", 0); err != nil {
+		t.Errorf("Expected no check without schedule_interval_min, got %s", err)
+	}
+	if err := validateKibanaTypedRuleInterval("30s", 30*time.Second); err != nil {
+		t.Errorf("Expected interval equal to schedule_interval_min to be valid, got %s", err)
+	}
+	if err := validateKibanaTypedRuleInterval("1m", 30*time.Second); err != nil {
+		t.Errorf("Expected interval longer than schedule_interval_min to be valid, got %s", err)
+	}
+	if err := validateKibanaTypedRuleInterval("10s", 30*time.Second); err == nil {
+		t.Error("Expected error when interval is shorter than schedule_interval_min")
+	}
+}
+
+func TestValidateKibanaTypedRuleAction(t *testing.T) {
+	if err := validateKibanaTypedRuleAction(0, true, false, true, false); err != nil {
+		t.Errorf("Expected action with connector_id to be valid, got %s", err)
+	}
+	if err := validateKibanaTypedRuleAction(0, false, true, false, true); err != nil {
+		t.Errorf("Expected action with connector_name and params_file to be valid, got %s", err)
+	}
+	if err := validateKibanaTypedRuleAction(0, true, true, false, false); err == nil {
+		t.Error("Expected error when action with connector_id and connector_name")
+	}
+	if err := validateKibanaTypedRuleAction(0, false, false, false, false); err == nil {
+		t.Error("Expected error when action without connector")
+	}
+	if err := validateKibanaTypedRuleAction(1, true, false, true, true); err == nil || !strings.HasPrefix(err.Error(), "action.1:") {
+		t.Errorf("Expected error on action.1 when action with params and params_file, got %v", err)
+	}
+}
+
+func TestSetKibanaTypedRuleConnectorIDs(t *testing.T) {
+	typedRule := &kibanaTypedRule{
+		ruleTypeID:   "test",
+		consumer:     "alerts",
+		actionGroups: []string{"threshold met"},
+		paramsSchema: map[string]*schema.Schema{},
+		buildParams: func(d *schema.ResourceData) (map[string]any, error) {
+			return map[string]any{}, nil
+		},
+	}
+	resource := resourceKibanaTypedRule(typedRule)
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "test",
+		"action": []interface{}{
+			map[string]interface{}{
+				"connector_id": "email",
+			},
+			map[string]interface{}{
+				"connector_name": "Slack SRE",
+			},
+		},
+	})
+	rule, err := buildKibanaTypedRule(d, typedRule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = setKibanaTypedRuleConnectorIDs(d, rule, func(names []string) (map[string]string, error) {
+		if len(names) != 1 || names[0] != "Slack SRE" {
+			t.Errorf("Expected to resolve only Slack SRE, got %+v", names)
+		}
+		return map[string]string{"Slack SRE": "slack-sre"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Actions[0].ID != "email" || rule.Actions[1].ID != "slack-sre" {
+		t.Errorf("Unexpected connector IDs: %s %s", rule.Actions[0].ID, rule.Actions[1].ID)
+	}
+}
+
+func TestKibanaRuleTypeUnsupportedReason(t *testing.T) {
+	ruleTypes := []kibanaAlertingRuleType{
+		{ID: ".index-threshold", EnabledInLicense: true, MinimumLicenseRequired: "basic"},
+		{ID: "xpack.ml.anomaly_detection_alert", EnabledInLicense: false, MinimumLicenseRequired: "platinum"},
+	}
+
+	if reason := kibanaRuleTypeUnsupportedReason(ruleTypes, ".index-threshold"); reason != "" {
+		t.Errorf("Expected rule type supported, got %s", reason)
+	}
+	if reason := kibanaRuleTypeUnsupportedReason(ruleTypes, "xpack.ml.anomaly_detection_alert"); reason != "Rule type xpack.ml.anomaly_detection_alert need platinum license" {
+		t.Errorf("Expected rule type not supported by license, got %s", reason)
+	}
+	if reason := kibanaRuleTypeUnsupportedReason(ruleTypes, "xpack.synthetics.alerts.tls"); reason != "Rule type xpack.synthetics.alerts.tls is not available on Kibana" {
+		t.Errorf("Expected rule type not available, got %s", reason)
+	}
+}
+
+func TestKibanaTypedRuleSkippedID(t *testing.T) {
+	id1 := kibanaTypedRuleSkippedID("team-a")
+	id2 := kibanaTypedRuleSkippedID("team-a")
+	if id1 == id2 {
+		t.Errorf("Expected unique ID for each skipped rule, got %s twice", id1)
+	}
+	space, ruleID, err := parseSpaceObjectID(id1)
+	if err != nil || space != "team-a" || !strings.HasPrefix(ruleID, "skipped-") {
+		t.Errorf("Unexpected skipped rule ID %s: %v", id1, err)
+	}
+}
+
+func TestMergeKibanaRuleParams(t *testing.T) {
+	currentParams := map[string]any{
+		"threshold":   []any{float64(10)},
+		"excludeHits": true,
+	}
+	params := map[string]any{
+		"threshold": []any{float64(20)},
+		"size":      100,
+	}
+
+	mergedParams := mergeKibanaRuleParams(currentParams, params)
+	if len(mergedParams) != 3 || mergedParams["excludeHits"] != true || mergedParams["size"] != 100 {
+		t.Errorf("Unexpected merged params: %+v", mergedParams)
+	}
+	if threshold := mergedParams["threshold"].([]any); threshold[0] != float64(20) {
+		t.Errorf("Expected threshold from resource, got %+v", threshold)
+	}
+	if currentParams["threshold"].([]any)[0] != float64(10) {
+		t.Error("Expected current params not changed")
+	}
+}
+
+func TestSetKibanaTypedRuleActionsFrequency(t *testing.T) {
+	throttle := "1h"
+	rule := &kibanaAlertingRule{
+		NotifyWhen: "onThrottleInterval",
+		Throttle:   &throttle,
+		Actions: []kibanaAlertingRuleAction{
+			{ID: "slack", Group: "threshold met"},
+			{ID: "email", Group: "recovered"},
+		},
+	}
+
+	setKibanaTypedRuleActionsFrequency(rule)
+	if rule.NotifyWhen != "" || rule.Throttle != nil {
+		t.Errorf("Expected no notify_when and throttle on rule, got %s %v", rule.NotifyWhen, rule.Throttle)
+	}
+	for _, action := range rule.Actions {
+		if action.Frequency == nil || action.Frequency.NotifyWhen != "onThrottleInterval" || *action.Frequency.Throttle != "1h" || action.Frequency.Summary {
+			t.Errorf("Unexpected frequency for action %s: %+v", action.ID, action.Frequency)
+		}
+	}
+}
+
+// testCheckKibanaTypedRuleDestroy permit to check that all rules of typed rule resource are deleted
+func TestCheckKibanaTypedRuleRevision(t *testing.T) {
+	if diags := checkKibanaTypedRuleRevision("warn", "default/rule-1", -1, 3); diags != nil {
+		t.Errorf("Expected no diagnostic when previous revision is unknown, got %+v", diags)
+	}
+	if diags := checkKibanaTypedRuleRevision("fail", "default/rule-1", 3, 3); diags != nil {
+		t.Errorf("Expected no diagnostic when revision not advanced, got %+v", diags)
+	}
+	if diags := checkKibanaTypedRuleRevision("ignore", "default/rule-1", 3, 4); diags != nil {
+		t.Errorf("Expected no diagnostic when manual edits are ignored, got %+v", diags)
+	}
+	if diags := checkKibanaTypedRuleRevision("warn", "default/rule-1", 0, 1); len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("Expected warning, got %+v", diags)
+	}
+	if diags := checkKibanaTypedRuleRevision("fail", "default/rule-1", 3, 4); diags != nil {
+		t.Errorf("Expected no diagnostic on refresh when manual edits fail the plan, got %+v", diags)
+	}
+}
+
+func TestValidateKibanaTypedRuleRevision(t *testing.T) {
+	if err := validateKibanaTypedRuleRevision("default/rule-1", 3, 3); err != nil {
+		t.Errorf("Expected no error when revision not advanced, got %s", err)
+	}
+	err := validateKibanaTypedRuleRevision("default/rule-1", 3, 4)
+	if err == nil {
+		t.Fatal("Expected error when revision advanced")
+	}
+	if !strings.Contains(err.Error(), `on_manual_edit = "warn"`) || !strings.Contains(err.Error(), "import") {
+		t.Errorf("Expected error explain how to reconcile, got %s", err)
+	}
+}
+
+func TestWaitKibanaAlertingRuleVisible(t *testing.T) {
+	nbReads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nbReads++
+		if nbReads <= 2 || r.URL.Path != "/api/alerting/rule/rule-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"rule-1","name":"test"}`))
+	}))
+	defer server.Close()
+	client := resty.New().SetBaseURL(server.URL)
+
+	// Visible after replication delay
+	rule, err := waitKibanaAlertingRuleVisible(context.Background(), client, "default", "rule-1", time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule == nil || rule.ID != "rule-1" || nbReads != 3 {
+		t.Errorf("Expected rule-1 after 3 reads, got %+v after %d reads", rule, nbReads)
+	}
+
+	// Never visible
+	nbReads = 0
+	rule, err = waitKibanaAlertingRuleVisible(context.Background(), client, "default", "rule-2", 50*time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule != nil || nbReads < 2 || nbReads > 5 {
+		t.Errorf("Expected no rule after some reads, got %+v after %d reads", rule, nbReads)
+	}
+}
+
+func testCheckKibanaTypedRuleDestroy(resourceType string) func(s *terraform.State) error {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			space, id, err := parseSpaceObjectID(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			meta := testAccProvider.Meta()
+
+			client := meta.(*kibanaMeta).client
+			rule, err := getKibanaAlertingRule(client.Client, space, id)
+			if err != nil {
+				return err
+			}
+			if rule != nil {
+				return fmt.Errorf("Rule %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
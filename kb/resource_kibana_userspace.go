@@ -8,14 +8,19 @@ package kb
 import (
 	"context"
 	"fmt"
+	"regexp"
 
-	kibana "github.com/disaster37/go-kibana-rest/v8"
 	kbapi "github.com/disaster37/go-kibana-rest/v8/kbapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	basePathKibanaSpaceApp = "/app/management/kibana/spaces/edit" // URL of space page on Kibana UI
+)
+
 // Resource specification to handle user space in Kibana
 func resourceKibanaUserSpace() *schema.Resource {
 	return &schema.Resource{
@@ -50,12 +55,23 @@ func resourceKibanaUserSpace() *schema.Resource {
 				},
 			},
 			"initials": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 2),
 			},
 			"color": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(#[0-9a-fA-F]{6})?$`), "must be a hex color like #aabbcc"),
+			},
+			"image_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(data:image/[a-z+.-]+;base64,[A-Za-z0-9+/=]+)?$`), "must be a base64 data URL like data:image/png;base64,..."),
+			},
+			"kibana_url": {
 				Type:     schema.TypeString,
-				Optional: true,
+				Computed: true,
 			},
 		},
 	}
@@ -69,21 +85,24 @@ func resourceKibanaUserSpaceCreate(ctx context.Context, d *schema.ResourceData,
 	disabledFeatures := convertArrayInterfaceToArrayString(d.Get("disabled_features").(*schema.Set).List())
 	initials := d.Get("initials").(string)
 	color := d.Get("color").(string)
-
-	client := meta.(*kibana.Client)
-
-	userSpace := &kbapi.KibanaSpace{
-		ID:               id,
-		Name:             name,
-		Description:      description,
-		DisabledFeatures: disabledFeatures,
-		Initials:         initials,
-		Color:            color,
+	imageURL := d.Get("image_url").(string)
+
+	client := meta.(*kibanaMeta).client
+
+	userSpace := &kibanaSpace{
+		KibanaSpace: kbapi.KibanaSpace{
+			ID:               id,
+			Name:             name,
+			Description:      description,
+			DisabledFeatures: disabledFeatures,
+			Initials:         initials,
+			Color:            color,
+		},
+		ImageURL: imageURL,
 	}
 
-	_, err := client.API.KibanaSpaces.Create(userSpace)
-	if err != nil {
-		return diag.FromErr(err)
+	if err := createKibanaSpace(client.Client, userSpace); err != nil {
+		return handleAPIError(err, "create user space")
 	}
 
 	d.SetId(id)
@@ -102,11 +121,11 @@ func resourceKibanaUserSpaceRead(ctx context.Context, d *schema.ResourceData, me
 
 	log.Debugf("User space id:  %s", id)
 
-	client := meta.(*kibana.Client)
+	client := meta.(*kibanaMeta).client
 
-	userSpace, err := client.API.KibanaSpaces.Get(id)
+	userSpace, err := getKibanaSpace(client.Client, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleAPIError(err, fmt.Sprintf("read user space %s", d.Id()))
 	}
 
 	if userSpace == nil {
@@ -116,7 +135,7 @@ func resourceKibanaUserSpaceRead(ctx context.Context, d *schema.ResourceData, me
 		return nil
 	}
 
-	log.Debugf("Get user space %s successfully:\n%s", id, userSpace)
+	log.Debugf("Get user space %s successfully:\n%+v", id, userSpace)
 
 	if err = d.Set("uid", id); err != nil {
 		return diag.FromErr(err)
@@ -136,6 +155,12 @@ func resourceKibanaUserSpaceRead(ctx context.Context, d *schema.ResourceData, me
 	if err = d.Set("color", userSpace.Color); err != nil {
 		return diag.FromErr(err)
 	}
+	if err = d.Set("image_url", userSpace.ImageURL); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("kibana_url", buildKibanaURL(client.Client.HostURL, "default", basePathKibanaSpaceApp, id)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	log.Infof("Read user space %s successfully", id)
 	fmt.Printf("[INFO] Read user space %s successfully", id)
@@ -151,20 +176,23 @@ func resourceKibanaUserSpaceUpdate(ctx context.Context, d *schema.ResourceData,
 	disabledFeatures := convertArrayInterfaceToArrayString(d.Get("disabled_features").(*schema.Set).List())
 	initials := d.Get("initials").(string)
 	color := d.Get("color").(string)
-
-	client := meta.(*kibana.Client)
-	userSpace := &kbapi.KibanaSpace{
-		ID:               id,
-		Name:             name,
-		Description:      description,
-		DisabledFeatures: disabledFeatures,
-		Initials:         initials,
-		Color:            color,
+	imageURL := d.Get("image_url").(string)
+
+	client := meta.(*kibanaMeta).client
+	userSpace := &kibanaSpace{
+		KibanaSpace: kbapi.KibanaSpace{
+			ID:               id,
+			Name:             name,
+			Description:      description,
+			DisabledFeatures: disabledFeatures,
+			Initials:         initials,
+			Color:            color,
+		},
+		ImageURL: imageURL,
 	}
 
-	_, err := client.API.KibanaSpaces.Update(userSpace)
-	if err != nil {
-		return diag.FromErr(err)
+	if err := updateKibanaSpace(client.Client, userSpace); err != nil {
+		return handleAPIError(err, fmt.Sprintf("update user space %s", d.Id()))
 	}
 
 	log.Infof("Updated user space %s successfully", id)
@@ -179,17 +207,17 @@ func resourceKibanaUserSpaceDelete(ctx context.Context, d *schema.ResourceData,
 	id := d.Id()
 	log.Debugf("User space id: %s", id)
 
-	client := meta.(*kibana.Client)
+	client := meta.(*kibanaMeta).client
 
 	err := client.API.KibanaSpaces.Delete(id)
 	if err != nil {
-		if err.(kbapi.APIError).Code == 404 {
+		if isAPIErrorNotFound(err) {
 			log.Warnf("User space %s not found - removing from state", id)
 			fmt.Printf("[WARN] User space %s not found - removing from state", id)
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return handleAPIError(err, fmt.Sprintf("delete user space %s", id))
 
 	}
 
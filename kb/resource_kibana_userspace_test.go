@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	kibana "github.com/disaster37/go-kibana-rest/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/pkg/errors"
@@ -23,6 +22,8 @@ func TestAccKibanaUserSpace(t *testing.T) {
 				Config: testKibanaUserSpace,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckKibanaUserSpaceExists("kibana_user_space.test"),
+					resource.TestCheckResourceAttrSet("kibana_user_space.test", "kibana_url"),
+					resource.TestCheckResourceAttrSet("kibana_user_space.test", "image_url"),
 				),
 			},
 			{
@@ -47,7 +48,7 @@ func testCheckKibanaUserSpaceExists(name string) resource.TestCheckFunc {
 
 		meta := testAccProvider.Meta()
 
-		client := meta.(*kibana.Client)
+		client := meta.(*kibanaMeta).client
 		userSpace, err := client.API.KibanaSpaces.Get(rs.Primary.ID)
 		if err != nil {
 			return err
@@ -68,7 +69,7 @@ func testCheckKibanaUserSpaceDestroy(s *terraform.State) error {
 
 		meta := testAccProvider.Meta()
 
-		client := meta.(*kibana.Client)
+		client := meta.(*kibanaMeta).client
 		userSpace, err := client.API.KibanaSpaces.Get(rs.Primary.ID)
 		if err != nil {
 			return err
@@ -90,6 +91,7 @@ resource "kibana_user_space" "test" {
   description 		= "test"
   initials			= "tt"
   color				= "#000000"
+  image_url			= "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg=="
   disabled_features = ["canvas", "maps", "advancedSettings", "indexPatterns", "graph", "monitoring", "ml", "apm", "infrastructure", "logs", "siem"]
 }
 `
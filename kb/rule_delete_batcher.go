@@ -0,0 +1,114 @@
+package kb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// ruleDeleteBatchIdle is the time without new rule deletion after which the batch is deleted, without waiting the whole window
+const ruleDeleteBatchIdle = 100 * time.Millisecond
+
+// kibanaRuleDeleteBatcher group the rule deletions of each space received together.
+// A batch is deleted when no other deletion is received during a short idle time, or at most after window.
+// When more than threshold rules are deleted together, like on destroy, they are deleted with one bulk delete call
+// to speed up the apply and reduce the load on task manager.
+// Terraform delete at most -parallelism resources at the same time, so the threshold must be lower than it.
+type kibanaRuleDeleteBatcher struct {
+	mutex     sync.Mutex
+	threshold int
+	window    time.Duration
+	idle      time.Duration
+	batches   map[string]*kibanaRuleDeleteBatch
+}
+
+// kibanaRuleDeleteBatch is the rules of one space waiting to be deleted
+type kibanaRuleDeleteBatch struct {
+	ids      []string
+	errs     map[string]error
+	done     chan struct{}
+	timer    *time.Timer
+	deadline time.Time
+}
+
+// newKibanaRuleDeleteBatcher return new rule delete batcher. The batching is disabled when threshold is 0
+func newKibanaRuleDeleteBatcher(threshold int, window time.Duration) *kibanaRuleDeleteBatcher {
+	idle := ruleDeleteBatchIdle
+	if window < idle {
+		idle = window
+	}
+
+	return &kibanaRuleDeleteBatcher{
+		threshold: threshold,
+		window:    window,
+		idle:      idle,
+		batches:   map[string]*kibanaRuleDeleteBatch{},
+	}
+}
+
+// delete permit to delete rule, and wait the end of its batch
+func (b *kibanaRuleDeleteBatcher) delete(client *resty.Client, space string, id string) error {
+	if b == nil || b.threshold <= 0 {
+		return deleteKibanaAlertingRule(client, space, id)
+	}
+
+	b.mutex.Lock()
+	batch, ok := b.batches[space]
+	if !ok {
+		batch = &kibanaRuleDeleteBatch{
+			ids:      make([]string, 0),
+			errs:     map[string]error{},
+			done:     make(chan struct{}),
+			deadline: time.Now().Add(b.window),
+		}
+		b.batches[space] = batch
+		batch.timer = time.AfterFunc(b.idle, func() {
+			b.flush(client, space, batch)
+		})
+	} else {
+		// Wait other deletions while they are received, but not more than window
+		wait := time.Until(batch.deadline)
+		if wait > b.idle {
+			wait = b.idle
+		}
+		batch.timer.Reset(wait)
+	}
+	batch.ids = append(batch.ids, id)
+	b.mutex.Unlock()
+
+	<-batch.done
+
+	return batch.errs[id]
+}
+
+// flush permit to delete the rules of batch, with bulk delete when there are more rules than threshold
+func (b *kibanaRuleDeleteBatcher) flush(client *resty.Client, space string, batch *kibanaRuleDeleteBatch) {
+	b.mutex.Lock()
+	if b.batches[space] != batch {
+		// Already flushed
+		b.mutex.Unlock()
+		return
+	}
+	delete(b.batches, space)
+	b.mutex.Unlock()
+	defer close(batch.done)
+
+	if len(batch.ids) <= b.threshold {
+		for _, id := range batch.ids {
+			batch.errs[id] = deleteKibanaAlertingRule(client, space, id)
+		}
+		return
+	}
+
+	log.Infof("Bulk delete %d rules of space %s", len(batch.ids), space)
+	errs, err := bulkDeleteKibanaAlertingRules(client, space, batch.ids)
+	for _, id := range batch.ids {
+		if err != nil {
+			batch.errs[id] = err
+		} else {
+			batch.errs[id] = errs[id]
+		}
+	}
+}
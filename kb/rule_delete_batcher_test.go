@@ -0,0 +1,119 @@
+package kb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestKibanaRuleDeleteBatcher(t *testing.T) {
+	var mutex sync.Mutex
+	nbDeletes := 0
+	bulkDeletes := make([][]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/s/team-a/internal/alerting/rules/_bulk_delete":
+			body := struct {
+				IDs []string `json:"ids"`
+			}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			bulkDeletes = append(bulkDeletes, body.IDs)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Saved object [alert/rule-3] not found","status":404,"rule":{"id":"rule-3","name":""}}],"total":3}`))
+		case r.Method == http.MethodDelete:
+			nbDeletes++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	client := resty.New().SetBaseURL(server.URL)
+
+	deleteRules := func(batcher *kibanaRuleDeleteBatcher, ids ...string) map[string]error {
+		var wg sync.WaitGroup
+		var errsMutex sync.Mutex
+		errs := map[string]error{}
+		for _, id := range ids {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				err := batcher.delete(client, "team-a", id)
+				errsMutex.Lock()
+				errs[id] = err
+				errsMutex.Unlock()
+			}(id)
+		}
+		wg.Wait()
+		return errs
+	}
+
+	// More rules than threshold
+	errs := deleteRules(newKibanaRuleDeleteBatcher(2, 50*time.Millisecond), "rule-1", "rule-2", "rule-3")
+	if len(bulkDeletes) != 1 || len(bulkDeletes[0]) != 3 || nbDeletes != 0 {
+		t.Errorf("Expected one bulk delete of 3 rules, got %+v and %d deletes", bulkDeletes, nbDeletes)
+	}
+	if errs["rule-1"] != nil || errs["rule-2"] != nil || !isAPIErrorNotFound(errs["rule-3"]) {
+		t.Errorf("Expected only rule-3 not found, got %+v", errs)
+	}
+
+	// Less rules than threshold
+	errs = deleteRules(newKibanaRuleDeleteBatcher(5, 50*time.Millisecond), "rule-4", "rule-5")
+	if len(bulkDeletes) != 1 || nbDeletes != 2 {
+		t.Errorf("Expected 2 deletes, got %d", nbDeletes)
+	}
+	if errs["rule-4"] != nil || errs["rule-5"] != nil {
+		t.Errorf("Expected no errors, got %+v", errs)
+	}
+
+	// Threshold reached by concurrent deletions received one after the other, like Terraform does with -parallelism
+	batcher := newKibanaRuleDeleteBatcher(10, 5*time.Second)
+	ids := make([]string, 0, 12)
+	for i := 1; i <= 12; i++ {
+		ids = append(ids, fmt.Sprintf("rule-bulk-%d", i))
+	}
+	var wg sync.WaitGroup
+	start := time.Now()
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if err := batcher.delete(client, "team-a", id); err != nil {
+				t.Error(err)
+			}
+		}(id)
+		time.Sleep(10 * time.Millisecond)
+	}
+	wg.Wait()
+	if len(bulkDeletes) != 2 || len(bulkDeletes[1]) != 12 || nbDeletes != 2 {
+		t.Errorf("Expected one bulk delete of 12 rules, got %+v and %d deletes", bulkDeletes, nbDeletes)
+	}
+	if time.Since(start) >= 5*time.Second {
+		t.Error("Expected batch deleted before the end of window")
+	}
+
+	// Alone deletion not wait the whole window
+	start = time.Now()
+	if err := newKibanaRuleDeleteBatcher(5, 5*time.Second).delete(client, "team-a", "rule-alone"); err != nil || nbDeletes != 3 {
+		t.Errorf("Expected direct delete, got %d deletes: %v", nbDeletes, err)
+	}
+	if time.Since(start) >= time.Second {
+		t.Errorf("Expected alone deletion not wait the window, wait %s", time.Since(start))
+	}
+
+	// Disabled
+	if err := newKibanaRuleDeleteBatcher(0, time.Second).delete(client, "team-a", "rule-6"); err != nil || nbDeletes != 4 {
+		t.Errorf("Expected direct delete, got %d deletes: %v", nbDeletes, err)
+	}
+}
@@ -0,0 +1,198 @@
+package kb
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// kibanaRulePolicy is the normalized description of rule, with stable field names, to write policies over plans with OPA or Sentinel
+type kibanaRulePolicy struct {
+	RuleTypeID      string                   `json:"rule_type_id"`
+	Space           string                   `json:"space"`
+	Name            string                   `json:"name"`
+	Consumer        string                   `json:"consumer"`
+	Enabled         bool                     `json:"enabled"`
+	Interval        string                   `json:"interval"`
+	IntervalSeconds int64                    `json:"interval_seconds"`
+	Tags            []string                 `json:"tags"`
+	NotifyWhen      string                   `json:"notify_when"`
+	Throttle        string                   `json:"throttle"`
+	Actions         []kibanaRulePolicyAction `json:"actions"`
+	Params          map[string]any           `json:"params"`
+}
+
+// kibanaRulePolicyAction is the normalized description of rule action, with the connector type resolved
+type kibanaRulePolicyAction struct {
+	ConnectorID     string `json:"connector_id"`
+	ConnectorTypeID string `json:"connector_type_id"`
+	Group           string `json:"group"`
+}
+
+// kibanaResourceGetter is the common interface of ResourceData and ResourceDiff to read attributes
+type kibanaResourceGetter interface {
+	Get(key string) interface{}
+}
+
+// kibanaRulePolicyKeys is the attributes, in addition to params, used to build rule policy
+var kibanaRulePolicyKeys = []string{"space", "name", "consumer", "enabled", "interval", "tags", "notify_when", "throttle"}
+
+// buildKibanaTypedRulePolicy permit to build the policy JSON of rule. The connector ID of each action is given, because it can be referenced by name
+func buildKibanaTypedRulePolicy(d kibanaResourceGetter, typedRule *kibanaTypedRule, connectorIDs []string, connectorTypes map[string]string) (string, error) {
+	interval, err := parseKibanaRuleInterval(d.Get("interval").(string))
+	if err != nil {
+		return "", err
+	}
+	tags := convertArrayInterfaceToArrayString(d.Get("tags").(*schema.Set).List())
+	sort.Strings(tags)
+
+	policy := &kibanaRulePolicy{
+		RuleTypeID:      typedRule.ruleTypeID,
+		Space:           d.Get("space").(string),
+		Name:            d.Get("name").(string),
+		Consumer:        d.Get("consumer").(string),
+		Enabled:         d.Get("enabled").(bool),
+		Interval:        d.Get("interval").(string),
+		IntervalSeconds: int64(interval.Seconds()),
+		Tags:            tags,
+		NotifyWhen:      d.Get("notify_when").(string),
+		Throttle:        d.Get("throttle").(string),
+		Actions:         make([]kibanaRulePolicyAction, 0, len(connectorIDs)),
+		Params:          make(map[string]any, len(typedRule.paramsSchema)),
+	}
+	actionsFrequency := false
+	for i, raw := range d.Get("action").([]interface{}) {
+		action := raw.(map[string]interface{})
+		policy.Actions = append(policy.Actions, kibanaRulePolicyAction{
+			ConnectorID:     connectorIDs[i],
+			ConnectorTypeID: connectorTypes[connectorIDs[i]],
+			Group:           action["group"].(string),
+		})
+		if frequencies, ok := action["frequency"].([]interface{}); ok && len(frequencies) > 0 {
+			actionsFrequency = true
+		}
+	}
+	// Notify when of rule is computed, so it's empty on new rules
+	if policy.NotifyWhen == "" && !actionsFrequency {
+		policy.NotifyWhen = defaultKibanaTypedRuleNotifyWhen
+	}
+	for key := range typedRule.paramsSchema {
+		policy.Params[key] = normalizeKibanaPolicyValue(d.Get(key))
+	}
+
+	b, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// normalizeKibanaPolicyValue permit to convert the sets of attribute value as lists, so they can be serialized as JSON
+func normalizeKibanaPolicyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		return normalizeKibanaPolicyValue(v.List())
+	case []interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			values = append(values, normalizeKibanaPolicyValue(item))
+		}
+		return values
+	case map[string]interface{}:
+		values := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			values[key] = normalizeKibanaPolicyValue(item)
+		}
+		return values
+	default:
+		return v
+	}
+}
+
+// setKibanaTypedRulePolicy permit to set the policy JSON of rule read from Kibana
+func setKibanaTypedRulePolicy(d *schema.ResourceData, meta interface{}, typedRule *kibanaTypedRule) error {
+	connectorIDs := make([]string, 0)
+	for _, raw := range d.Get("action").([]interface{}) {
+		connectorIDs = append(connectorIDs, raw.(map[string]interface{})["connector_id"].(string))
+	}
+
+	connectorTypes := map[string]string{}
+	if len(connectorIDs) > 0 {
+		var err error
+		connectorTypes, err = meta.(*kibanaMeta).connectors.connectorTypes(meta.(*kibanaMeta).client.Client, d.Get("space").(string), connectorIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	policy, err := buildKibanaTypedRulePolicy(d, typedRule, connectorIDs, connectorTypes)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("policy_json", policy)
+}
+
+// customizeKibanaTypedRulePolicy permit to compute the policy JSON of rule at plan time, so policies can read it on plan.
+// It's known after apply when an attribute is known only on apply
+func customizeKibanaTypedRulePolicy(d *schema.ResourceDiff, meta interface{}, typedRule *kibanaTypedRule) error {
+	providerMeta, ok := meta.(*kibanaMeta)
+	if !ok {
+		return nil
+	}
+
+	keys := append(append(make([]string, 0, len(kibanaRulePolicyKeys)+len(typedRule.paramsSchema)+1), kibanaRulePolicyKeys...), "action")
+	for key := range typedRule.paramsSchema {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("policy_json")
+		}
+	}
+
+	// Connector referenced by name has its ID computed on apply
+	space := d.Get("space").(string)
+	actions := d.Get("action").([]interface{})
+	connectorIDs := make([]string, len(actions))
+	names := make([]string, 0)
+	for i, raw := range actions {
+		action := raw.(map[string]interface{})
+		if connectorIDs[i] = action["connector_id"].(string); connectorIDs[i] == "" && action["connector_name"].(string) != "" {
+			names = append(names, action["connector_name"].(string))
+		}
+	}
+	if len(names) > 0 {
+		ids, err := providerMeta.connectors.resolve(providerMeta.client.Client, space, names)
+		if err != nil {
+			return fmt.Errorf("Error when resolve connectors of policy_json: %s", err.Error())
+		}
+		for i, raw := range actions {
+			if name := raw.(map[string]interface{})["connector_name"].(string); connectorIDs[i] == "" && name != "" {
+				connectorIDs[i] = ids[name]
+			}
+		}
+	}
+
+	connectorTypes := map[string]string{}
+	if len(connectorIDs) > 0 {
+		var err error
+		connectorTypes, err = providerMeta.connectors.connectorTypes(providerMeta.client.Client, space, connectorIDs)
+		if err != nil {
+			return fmt.Errorf("Error when read connectors of policy_json: %s", err.Error())
+		}
+	}
+
+	policy, err := buildKibanaTypedRulePolicy(d, typedRule, connectorIDs, connectorTypes)
+	if err != nil {
+		return err
+	}
+	if policy == d.Get("policy_json").(string) {
+		return nil
+	}
+
+	return d.SetNew("policy_json", policy)
+}
@@ -0,0 +1,83 @@
+package kb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestBuildKibanaTypedRulePolicy(t *testing.T) {
+	typedRule := &kibanaTypedRule{
+		ruleTypeID:   ".es-query",
+		consumer:     "alerts",
+		actionGroups: []string{"query matched"},
+		paramsSchema: map[string]*schema.Schema{
+			"esql": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"query": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"time_field": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	resource := resourceKibanaTypedRule(typedRule)
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"space":    "team-a",
+		"name":     "test",
+		"tags":     []interface{}{"b", "a"},
+		"interval": "5m",
+		"enabled":  true,
+		"esql": []interface{}{
+			map[string]interface{}{
+				"query":      "FROM logs-*",
+				"time_field": "@timestamp",
+			},
+		},
+		"action": []interface{}{
+			map[string]interface{}{
+				"connector_name": "Slack SRE",
+			},
+			map[string]interface{}{
+				"connector_id": "email",
+				"group":        "recovered",
+			},
+		},
+	})
+
+	policyJSON, err := buildKibanaTypedRulePolicy(d, typedRule, []string{"slack-sre", "email"}, map[string]string{"slack-sre": ".slack", "email": ".email"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := &kibanaRulePolicy{}
+	if err = json.Unmarshal([]byte(policyJSON), policy); err != nil {
+		t.Fatal(err)
+	}
+
+	if policy.RuleTypeID != ".es-query" || policy.Space != "team-a" || policy.Consumer != "alerts" || !policy.Enabled {
+		t.Errorf("Unexpected rule settings: %+v", policy)
+	}
+	if policy.IntervalSeconds != 300 {
+		t.Errorf("Expected interval_seconds 300, got %d", policy.IntervalSeconds)
+	}
+	if len(policy.Tags) != 2 || policy.Tags[0] != "a" {
+		t.Errorf("Expected sorted tags, got %+v", policy.Tags)
+	}
+	if len(policy.Actions) != 2 || policy.Actions[0].ConnectorTypeID != ".slack" || policy.Actions[0].Group != "query matched" || policy.Actions[1].ConnectorTypeID != ".email" || policy.Actions[1].Group != "recovered" {
+		t.Errorf("Expected actions with connector types, got %+v", policy.Actions)
+	}
+	esql, _ := policy.Params["esql"].([]any)
+	if len(esql) != 1 || esql[0].(map[string]any)["query"] != "FROM logs-*" {
+		t.Errorf("Expected esql params, got %+v", policy.Params)
+	}
+}
@@ -0,0 +1,111 @@
+//go:build serverless
+
+// Acceptance tests against serverless project, where some alerting fields differ.
+// Run them with `make testacc-serverless`, KIBANA_URL and KIBANA_API_KEY must target the project.
+
+package kb
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccPreCheckServerless(t *testing.T) {
+	testAccPreCheck(t)
+
+	if v := os.Getenv("KIBANA_API_KEY"); v == "" {
+		t.Fatal("KIBANA_API_KEY must be set for serverless acceptance tests")
+	}
+}
+
+func TestAccServerlessProviderInfo(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckServerless(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKibanaProviderInfo,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kibana_provider_info.test", "version"),
+					resource.TestCheckResourceAttr("data.kibana_provider_info.test", "serverless", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerlessKibanaIndexThresholdRule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckServerless(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckKibanaTypedRuleDestroy("kibana_index_threshold_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testServerlessKibanaIndexThresholdRule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("kibana_index_threshold_rule.test", "rule_id"),
+					resource.TestCheckResourceAttr("kibana_index_threshold_rule.test", "notify_when", "onActionGroupChange"),
+				),
+			},
+			{
+				Config: testServerlessKibanaIndexThresholdRuleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_index_threshold_rule.test", "notify_when", "onThrottleInterval"),
+					resource.TestCheckResourceAttr("kibana_index_threshold_rule.test", "throttle", "1h"),
+				),
+			},
+			{
+				ResourceName:      "kibana_index_threshold_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Use the email connector preconfigured on Elastic Cloud
+var testServerlessKibanaIndexThresholdRule = `
+resource kibana_index_threshold_rule "test" {
+  name                 = "terraform-test"
+  index                = ["terraform-test"]
+  time_field           = "@timestamp"
+  threshold_comparator = ">"
+  threshold            = [100]
+
+  action {
+    connector_id = "elastic-cloud-email"
+    params = jsonencode({
+      to      = ["terraform-test@example.com"]
+      subject = "terraform-test"
+      message = "{{context.message}}"
+    })
+  }
+}
+`
+
+var testServerlessKibanaIndexThresholdRuleUpdate = `
+resource kibana_index_threshold_rule "test" {
+  name                 = "terraform-test"
+  index                = ["terraform-test"]
+  time_field           = "@timestamp"
+  threshold_comparator = ">"
+  threshold            = [100]
+  notify_when          = "onThrottleInterval"
+  throttle             = "1h"
+
+  action {
+    connector_id = "elastic-cloud-email"
+    params = jsonencode({
+      to      = ["terraform-test@example.com"]
+      subject = "terraform-test"
+      message = "{{context.message}}"
+    })
+  }
+}
+`
@@ -0,0 +1,154 @@
+package kb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/disaster37/terraform-provider-kibana" // Name of tracer and service that emit spans
+)
+
+var (
+	tracerOnce     sync.Once
+	tracerProvider *sdktrace.TracerProvider
+)
+
+// tracingEnabled return true if OTLP endpoint is set on environment variables
+func tracingEnabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// getTracerProvider return the tracer provider that export spans with OTLP over HTTP.
+// The exporter is configured by the standard OTEL_EXPORTER_OTLP_* environment variables.
+// Spans are exported synchronously, because the provider process can be killed at any time by Terraform.
+func getTracerProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
+	var err error
+	tracerOnce.Do(func() {
+		var exporter *otlptrace.Exporter
+		exporter, err = otlptracehttp.New(ctx)
+		if err != nil {
+			return
+		}
+		tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithSyncer(exporter),
+			sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName("terraform-provider-kibana"))),
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error when init OpenTelemetry exporter: %w", err)
+	}
+
+	return tracerProvider, nil
+}
+
+// kibanaRouteSegments is the static path segments of Kibana API. The other segments, like IDs, are replaced on span names,
+// so the number of span names stay bounded
+var kibanaRouteSegments = func() map[string]bool {
+	segments := map[string]bool{}
+	for _, segment := range strings.Fields(`api internal actions connector connector_types connectors alerting rule rule_types rules
+		cases console proxy data_views default detection_engine prepackaged preview features fleet epm packages infra log_views
+		custom-dashboards kibana settings licensing info metrics source observability annotation saved_objects resolve security role
+		spaces space timeline apm apm-index-settings apm-indices save me dashboards logstash pipeline short_url status export import`) {
+		segments[segment] = true
+	}
+
+	return segments
+}()
+
+// tracingParentKey is the context key of the context given by caller, so the span of each attempt is created from it
+type tracingParentKey struct{}
+
+// kibanaRouteTemplate return the path of API call, with space and IDs replaced by placeholders, like /s/{space}/api/alerting/rule/{id}.
+// Segments that start with _, like _find, are actions and are kept
+func kibanaRouteTemplate(rawURL string) string {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.Path
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		switch {
+		case i == 1 && segments[0] == "s":
+			segments[i] = "{space}"
+		case segment == "", i == 0 && segment == "s", strings.HasPrefix(segment, "_"), kibanaRouteSegments[segment]:
+		default:
+			segments[i] = "{id}"
+		}
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
+
+// instrumentClient permit to create one span by API call.
+// Spans are children of the span of request context, and are named with method and route template. The full URL is on http.url attribute.
+func instrumentClient(c *resty.Client, tracer trace.Tracer) {
+	c.OnBeforeRequest(func(c *resty.Client, req *resty.Request) error {
+		parent := req.Context()
+		// End span of previous attempt when request is retried, and create the new span from the same parent
+		if previous, ok := parent.Value(tracingParentKey{}).(context.Context); ok {
+			if span := trace.SpanFromContext(parent); span.IsRecording() {
+				span.SetStatus(codes.Error, "retried")
+				span.End()
+			}
+			parent = previous
+		}
+
+		fullURL := req.URL
+		if !strings.HasPrefix(fullURL, "http://") && !strings.HasPrefix(fullURL, "https://") {
+			fullURL = strings.TrimSuffix(c.BaseURL, "/") + fullURL
+		}
+		route := kibanaRouteTemplate(req.URL)
+
+		ctx, _ := tracer.Start(parent, fmt.Sprintf("%s %s", req.Method, route),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.HTTPMethod(req.Method),
+				attribute.String("http.route", route),
+				attribute.String("http.url", fullURL),
+			),
+		)
+		req.SetContext(context.WithValue(ctx, tracingParentKey{}, parent))
+
+		return nil
+	})
+
+	c.OnAfterResponse(func(c *resty.Client, resp *resty.Response) error {
+		span := trace.SpanFromContext(resp.Request.Context())
+		span.SetAttributes(semconv.HTTPStatusCode(resp.StatusCode()))
+		if resp.StatusCode() >= 300 {
+			span.SetStatus(codes.Error, resp.Status())
+		}
+		span.End()
+
+		return nil
+	})
+
+	c.OnError(func(req *resty.Request, err error) {
+		span := trace.SpanFromContext(req.Context())
+		if !span.IsRecording() {
+			return
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+	})
+
+	log.Debugf("API calls are traced with OpenTelemetry")
+}
@@ -0,0 +1,101 @@
+package kb
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrumentClient(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "endpoints.json")
+	if err := os.WriteFile(file, []byte(`[{"path": "/api/status", "body": {}}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	transport, err := newMockTransport(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client := resty.New().SetTransport(transport).SetBaseURL("http://kibana:5601")
+	instrumentClient(client, tp.Tracer(tracerName))
+
+	if _, err = client.R().Get("/api/status"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.R().Get("/api/unknown"); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Name() != "GET /api/status" {
+		t.Errorf("Unexpected span name: %s", spans[0].Name())
+	}
+	if !hasAttribute(spans[0].Attributes(), attribute.Int("http.status_code", http.StatusOK)) {
+		t.Errorf("Expected status code on span, got %+v", spans[0].Attributes())
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Errorf("Expected error status on span, got %+v", spans[1].Status())
+	}
+	if !hasAttribute(spans[0].Attributes(), attribute.String("http.url", "http://kibana:5601/api/status")) {
+		t.Errorf("Expected full URL on span, got %+v", spans[0].Attributes())
+	}
+
+	// Span is child of the span of request context, and named with route template
+	ctx, parent := tp.Tracer(tracerName).Start(context.Background(), "apply")
+	if _, err = client.R().SetContext(ctx).Get("/s/team-a/api/alerting/rule/3b9d2c4e-rule"); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+	spans = recorder.Ended()
+	span := spans[2]
+	if span.Name() != "GET /s/{space}/api/alerting/rule/{id}" {
+		t.Errorf("Unexpected span name: %s", span.Name())
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() || span.SpanContext().TraceID() != parent.SpanContext().TraceID() {
+		t.Error("Expected span to join the trace of request context")
+	}
+	if !hasAttribute(span.Attributes(), attribute.String("http.url", "http://kibana:5601/s/team-a/api/alerting/rule/3b9d2c4e-rule")) {
+		t.Errorf("Expected full URL on span, got %+v", span.Attributes())
+	}
+	if spans[3].Name() != "apply" || spans[3].EndTime().IsZero() {
+		t.Errorf("Expected parent span not ended by API call span, got %s", spans[3].Name())
+	}
+}
+
+func TestKibanaRouteTemplate(t *testing.T) {
+	routes := map[string]string{
+		"/api/status": "/api/status",
+		"http://kibana:5601/api/saved_objects/_find?page=2": "/api/saved_objects/_find",
+		"/s/team-a/api/alerting/rule/my-rule/_enable":       "/s/{space}/api/alerting/rule/{id}/_enable",
+		"/api/infra/host/custom-dashboards/123":             "/api/infra/{id}/custom-dashboards/{id}",
+		"/internal/alerting/rules/_bulk_delete":             "/internal/alerting/rules/_bulk_delete",
+	}
+	for rawURL, expected := range routes {
+		if route := kibanaRouteTemplate(rawURL); route != expected {
+			t.Errorf("Expected route %s for %s, got %s", expected, rawURL, route)
+		}
+	}
+}
+
+func hasAttribute(attributes []attribute.KeyValue, expected attribute.KeyValue) bool {
+	for _, a := range attributes {
+		if a == expected {
+			return true
+		}
+	}
+	return false
+}
@@ -2,7 +2,12 @@ package kb
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // optionalInterfaceJSON permit to convert string as json object
@@ -14,6 +19,11 @@ func optionalInterfaceJSON(input string) interface{} {
 
 }
 
+// defaultSpaceFunc permit to use environment variable KIBANA_SPACE as default space, or `default`
+func defaultSpaceFunc() schema.SchemaDefaultFunc {
+	return schema.EnvDefaultFunc("KIBANA_SPACE", "default")
+}
+
 // convertArrayInterfaceToArrayString permit to convert an array of interface to an array of string
 func convertArrayInterfaceToArrayString(raws []interface{}) []string {
 	data := make([]string, len(raws))
@@ -24,6 +34,16 @@ func convertArrayInterfaceToArrayString(raws []interface{}) []string {
 	return data
 }
 
+// convertMapInterfaceToMapString permit to convert a map of interface to a map of string
+func convertMapInterfaceToMapString(raws map[string]interface{}) map[string]string {
+	data := make(map[string]string, len(raws))
+	for key, raw := range raws {
+		data[key] = raw.(string)
+	}
+
+	return data
+}
+
 func convertInterfaceToJsonString(object interface{}) (string, error) {
 	if object == nil {
 		return "", nil
@@ -40,3 +60,32 @@ func convertInterfaceToJsonString(object interface{}) (string, error) {
 
 	return string(b), nil
 }
+
+// buildPath permit to compose API path from base path and segments, like IDs.
+// Segments are escaped, so IDs can contain any characters.
+func buildPath(basePath string, segments ...string) string {
+	var sb strings.Builder
+	sb.WriteString(basePath)
+	for _, segment := range segments {
+		sb.WriteString("/")
+		sb.WriteString(url.PathEscape(segment))
+	}
+
+	return sb.String()
+}
+
+// buildSpacePath permit to compose API path and prefix it with the space, like Kibana expect it.
+// All raw API calls must compose their path with it or with buildPath, so space handling stay on one place.
+func buildSpacePath(space string, basePath string, segments ...string) string {
+	path := buildPath(basePath, segments...)
+	if space == "" || space == "default" {
+		return path
+	}
+
+	return fmt.Sprintf("/s/%s%s", url.PathEscape(space), path)
+}
+
+// buildKibanaURL permit to compose the URL of Kibana UI page, from provider URL (with its base path) and the space
+func buildKibanaURL(hostURL string, space string, appPath string, segments ...string) string {
+	return strings.TrimSuffix(hostURL, "/") + buildSpacePath(space, appPath, segments...)
+}
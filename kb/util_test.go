@@ -0,0 +1,36 @@
+package kb
+
+import (
+	"testing"
+)
+
+func TestBuildSpacePath(t *testing.T) {
+	testCases := []struct {
+		space    string
+		basePath string
+		segments []string
+		expected string
+	}{
+		{"", "/api/cases", nil, "/api/cases"},
+		{"default", "/api/cases", []string{"abc"}, "/api/cases/abc"},
+		{"team-a", "/api/cases", nil, "/s/team-a/api/cases"},
+		{"team-a", "/api/infra", []string{"host", "custom-dashboards", "abc"}, "/s/team-a/api/infra/host/custom-dashboards/abc"},
+		{"team-a", "/api/saved_objects/resolve", []string{"dashboard", "my id/1"}, "/s/team-a/api/saved_objects/resolve/dashboard/my%20id%2F1"},
+	}
+
+	for _, testCase := range testCases {
+		if path := buildSpacePath(testCase.space, testCase.basePath, testCase.segments...); path != testCase.expected {
+			t.Errorf("Expected %s, got %s", testCase.expected, path)
+		}
+	}
+
+	// Kibana UI keep the base path of provider URL
+	if kibanaURL := buildKibanaURL("https://company.com/kibana/", "team-a", basePathKibanaDashboardApp, "abc"); kibanaURL != "https://company.com/kibana/s/team-a/app/dashboards#/view/abc" {
+		t.Errorf("Expected dashboard URL, got %s", kibanaURL)
+	}
+
+	// Global API not depend on space
+	if path := buildPath("/api/fleet/epm/packages", "security_detection_engine", "8.5.0"); path != "/api/fleet/epm/packages/security_detection_engine/8.5.0" {
+		t.Errorf("Expected fleet package path, got %s", path)
+	}
+}
@@ -1,15 +1,20 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 
 	"github.com/disaster37/terraform-provider-kibana/v8/kb"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	log "github.com/sirupsen/logrus"
 	easy "github.com/t-tomalak/logrus-easy-formatter"
 )
 
+// Set by goreleaser
+var version = "dev"
+
 func init() {
 
 	log.SetOutput(os.Stderr)
@@ -23,10 +28,18 @@ func init() {
 func main() {
 
 	var debugMode bool
+	var checkMode bool
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.BoolVar(&checkMode, "check", false, "set to true to check the connexion on Kibana with the settings provided by environment variables")
 	flag.Parse()
 
+	kb.Version = version
+
+	if checkMode {
+		os.Exit(check())
+	}
+
 	opts := &plugin.ServeOpts{
 		ProviderFunc: kb.Provider,
 		Debug:        debugMode,
@@ -35,3 +48,18 @@ func main() {
 	plugin.Serve(opts)
 
 }
+
+// check permit to configure the provider from environment variables and report if Kibana is reachable
+func check() int {
+	provider := kb.Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	if diags.HasError() {
+		for _, d := range diags {
+			log.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+		return 1
+	}
+
+	log.Info("Kibana is reachable")
+	return 0
+}